@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/urfave/cli"
 
@@ -54,6 +55,173 @@ func main() {
 				Name:   "mongoQueryTimeout",
 				EnvVar: "MONGO_QUERY_TIMEOUT",
 			},
+			&cli.DurationFlag{
+				Name:   "fetchTimeout",
+				EnvVar: "FETCH_TIMEOUT",
+			},
+			&cli.DurationFlag{
+				Name:   "fetchJobHeartbeat",
+				EnvVar: "FETCH_JOB_HEARTBEAT",
+				Value:  5 * time.Second,
+			},
+			&cli.IntFlag{
+				Name:   "fetchMaxErrors",
+				EnvVar: "FETCH_MAX_ERRORS",
+				Value:  100,
+			},
+			&cli.IntFlag{
+				Name:   "fetchBatchSize",
+				EnvVar: "FETCH_BATCH_SIZE",
+				Value:  1000,
+			},
+			&cli.IntFlag{
+				Name:   "fetchBatchQueue",
+				EnvVar: "FETCH_BATCH_QUEUE",
+				Value:  2,
+			},
+			&cli.IntFlag{
+				Name:   "fetchMaxDiffItems",
+				EnvVar: "FETCH_MAX_DIFF_ITEMS",
+				Value:  1000,
+			},
+			&cli.DurationFlag{
+				Name:   "scheduleTick",
+				EnvVar: "SCHEDULE_TICK",
+				Value:  10 * time.Second,
+			},
+			&cli.DurationFlag{
+				Name:   "scheduleLease",
+				EnvVar: "SCHEDULE_LEASE",
+				Value:  30 * time.Second,
+			},
+			&cli.Int64Flag{
+				Name:   "fetchMaxDecompressedSize",
+				EnvVar: "FETCH_MAX_DECOMPRESSED_SIZE",
+				Value:  4 << 30,
+			},
+			&cli.Float64Flag{
+				Name:   "fetchMaxCompressionRatio",
+				EnvVar: "FETCH_MAX_COMPRESSION_RATIO",
+				Value:  100,
+			},
+			&cli.StringSliceFlag{
+				Name:   "fetchAllowedSchemes",
+				EnvVar: "FETCH_ALLOWED_SCHEMES",
+				Value:  &cli.StringSlice{"http", "https"},
+			},
+			&cli.StringSliceFlag{
+				Name:   "fetchAllowedHosts",
+				EnvVar: "FETCH_ALLOWED_HOSTS",
+			},
+			&cli.StringSliceFlag{
+				Name:   "fetchDeniedHosts",
+				EnvVar: "FETCH_DENIED_HOSTS",
+			},
+			&cli.BoolFlag{
+				Name:   "fetchAllowPrivate",
+				EnvVar: "FETCH_ALLOW_PRIVATE",
+			},
+			&cli.IntFlag{
+				Name:   "fetchMaxRedirects",
+				EnvVar: "FETCH_MAX_REDIRECTS",
+				Value:  5,
+			},
+			&cli.Int64Flag{
+				Name:   "fetchMaxResponseSize",
+				EnvVar: "FETCH_MAX_RESPONSE_SIZE",
+				Value:  1 << 30,
+			},
+			&cli.StringFlag{
+				Name:   "secretsKey",
+				EnvVar: "SECRETS_KEY",
+			},
+			&cli.StringFlag{
+				Name:   "secretsDir",
+				EnvVar: "SECRETS_DIR",
+				Value:  "/run/secrets",
+			},
+			&cli.IntFlag{
+				Name:   "fetchRetryAttempts",
+				EnvVar: "FETCH_RETRY_ATTEMPTS",
+				Value:  3,
+			},
+			&cli.DurationFlag{
+				Name:   "fetchRetryBaseDelay",
+				EnvVar: "FETCH_RETRY_BASE_DELAY",
+				Value:  time.Second,
+			},
+			&cli.DurationFlag{
+				Name:   "fetchRetryMaxDelay",
+				EnvVar: "FETCH_RETRY_MAX_DELAY",
+				Value:  30 * time.Second,
+			},
+			&cli.IntFlag{
+				Name:   "fetchBreakerFailures",
+				EnvVar: "FETCH_BREAKER_FAILURES",
+				Value:  5,
+			},
+			&cli.DurationFlag{
+				Name:   "fetchBreakerCooldown",
+				EnvVar: "FETCH_BREAKER_COOLDOWN",
+				Value:  5 * time.Minute,
+			},
+			&cli.IntFlag{
+				Name:   "metricsPort",
+				EnvVar: "METRICS_PORT",
+			},
+			&cli.StringSliceFlag{
+				Name:   "fetchFileRoots",
+				EnvVar: "FETCH_FILE_ROOTS",
+			},
+			&cli.StringFlag{
+				Name:   "inboxDir",
+				EnvVar: "INBOX_DIR",
+			},
+			&cli.DurationFlag{
+				Name:   "inboxPoll",
+				EnvVar: "INBOX_POLL",
+				Value:  10 * time.Second,
+			},
+			&cli.DurationFlag{
+				Name:   "inboxSettle",
+				EnvVar: "INBOX_SETTLE",
+				Value:  5 * time.Second,
+			},
+			&cli.StringFlag{
+				Name:   "s3Endpoint",
+				EnvVar: "S3_ENDPOINT",
+			},
+			&cli.StringFlag{
+				Name:   "s3Region",
+				EnvVar: "S3_REGION",
+			},
+			&cli.StringFlag{
+				Name:   "s3AccessKeyId",
+				EnvVar: "S3_ACCESS_KEY_ID",
+			},
+			&cli.StringFlag{
+				Name:   "s3SecretAccessKey",
+				EnvVar: "S3_SECRET_ACCESS_KEY",
+			},
+			&cli.BoolFlag{
+				Name:   "s3PathStyle",
+				EnvVar: "S3_PATH_STYLE",
+			},
+			&cli.IntFlag{
+				Name:   "fetchWorkers",
+				EnvVar: "FETCH_WORKERS",
+				Value:  8,
+			},
+			&cli.IntFlag{
+				Name:   "fetchWorkersPerHost",
+				EnvVar: "FETCH_WORKERS_PER_HOST",
+				Value:  2,
+			},
+			&cli.IntFlag{
+				Name:   "fetchBatchMaxFeeds",
+				EnvVar: "FETCH_BATCH_MAX_FEEDS",
+				Value:  100,
+			},
 		},
 	}
 
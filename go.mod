@@ -3,13 +3,15 @@ module github.com/marknovikov/products-demo
 go 1.15
 
 require (
+	github.com/aws/aws-sdk-go v1.34.28
 	github.com/golang/protobuf v1.4.3 // indirect
+	github.com/klauspost/compress v1.9.5
 	github.com/shopspring/decimal v1.2.0
 	github.com/urfave/cli v1.22.5
 	go.mongodb.org/mongo-driver v1.4.4
 	golang.org/x/net v0.0.0-20201216054612-986b41b23924 // indirect
 	golang.org/x/sys v0.0.0-20201218084310-7d0127a74742 // indirect
-	golang.org/x/text v0.3.4 // indirect
+	golang.org/x/text v0.3.4
 	google.golang.org/genproto v0.0.0-20201214200347-8c77b98c765d // indirect
 	google.golang.org/grpc v1.34.0
 	google.golang.org/protobuf v1.25.0
@@ -17,6 +17,49 @@ type Config struct {
 	MongoDatabase     string
 	MongoConnTimeout  time.Duration
 	MongoQueryTimeout time.Duration
+	FetchTimeout      time.Duration
+	FetchJobHeartbeat time.Duration
+	FetchMaxErrors    int
+	FetchBatchSize    int
+	FetchBatchQueue   int
+	FetchMaxDiffItems int
+	ScheduleTick      time.Duration
+	ScheduleLease     time.Duration
+
+	FetchMaxDecompressedSize int64
+	FetchMaxCompressionRatio float64
+
+	FetchAllowedSchemes  []string
+	FetchAllowedHosts    []string
+	FetchDeniedHosts     []string
+	FetchAllowPrivate    bool
+	FetchMaxRedirects    int
+	FetchMaxResponseSize int64
+
+	SecretsKey string
+	SecretsDir string
+
+	FetchRetryAttempts   int
+	FetchRetryBaseDelay  time.Duration
+	FetchRetryMaxDelay   time.Duration
+	FetchBreakerFailures int
+	FetchBreakerCooldown time.Duration
+	MetricsPort          int
+
+	FetchFileRoots []string
+	InboxDir       string
+	InboxPoll      time.Duration
+	InboxSettle    time.Duration
+
+	S3Endpoint        string
+	S3Region          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3PathStyle       bool
+
+	FetchWorkers        int
+	FetchWorkersPerHost int
+	FetchBatchMaxFeeds  int
 }
 
 func New(c *cli.Context) Config {
@@ -31,5 +74,48 @@ func New(c *cli.Context) Config {
 		MongoDatabase:     c.String("mongoDatabase"),
 		MongoConnTimeout:  c.Duration("mongoConnTimeout"),
 		MongoQueryTimeout: c.Duration("mongoQueryTimeout"),
+		FetchTimeout:      c.Duration("fetchTimeout"),
+		FetchJobHeartbeat: c.Duration("fetchJobHeartbeat"),
+		FetchMaxErrors:    c.Int("fetchMaxErrors"),
+		FetchBatchSize:    c.Int("fetchBatchSize"),
+		FetchBatchQueue:   c.Int("fetchBatchQueue"),
+		FetchMaxDiffItems: c.Int("fetchMaxDiffItems"),
+		ScheduleTick:      c.Duration("scheduleTick"),
+		ScheduleLease:     c.Duration("scheduleLease"),
+
+		FetchMaxDecompressedSize: c.Int64("fetchMaxDecompressedSize"),
+		FetchMaxCompressionRatio: c.Float64("fetchMaxCompressionRatio"),
+
+		FetchAllowedSchemes:  c.StringSlice("fetchAllowedSchemes"),
+		FetchAllowedHosts:    c.StringSlice("fetchAllowedHosts"),
+		FetchDeniedHosts:     c.StringSlice("fetchDeniedHosts"),
+		FetchAllowPrivate:    c.Bool("fetchAllowPrivate"),
+		FetchMaxRedirects:    c.Int("fetchMaxRedirects"),
+		FetchMaxResponseSize: c.Int64("fetchMaxResponseSize"),
+
+		SecretsKey: c.String("secretsKey"),
+		SecretsDir: c.String("secretsDir"),
+
+		FetchRetryAttempts:   c.Int("fetchRetryAttempts"),
+		FetchRetryBaseDelay:  c.Duration("fetchRetryBaseDelay"),
+		FetchRetryMaxDelay:   c.Duration("fetchRetryMaxDelay"),
+		FetchBreakerFailures: c.Int("fetchBreakerFailures"),
+		FetchBreakerCooldown: c.Duration("fetchBreakerCooldown"),
+		MetricsPort:          c.Int("metricsPort"),
+
+		FetchFileRoots: c.StringSlice("fetchFileRoots"),
+		InboxDir:       c.String("inboxDir"),
+		InboxPoll:      c.Duration("inboxPoll"),
+		InboxSettle:    c.Duration("inboxSettle"),
+
+		S3Endpoint:        c.String("s3Endpoint"),
+		S3Region:          c.String("s3Region"),
+		S3AccessKeyID:     c.String("s3AccessKeyId"),
+		S3SecretAccessKey: c.String("s3SecretAccessKey"),
+		S3PathStyle:       c.Bool("s3PathStyle"),
+
+		FetchWorkers:        c.Int("fetchWorkers"),
+		FetchWorkersPerHost: c.Int("fetchWorkersPerHost"),
+		FetchBatchMaxFeeds:  c.Int("fetchBatchMaxFeeds"),
 	}
 }
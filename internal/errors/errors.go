@@ -31,3 +31,51 @@ func (err ErrInternal) Error() string {
 func (err ErrInternal) Unwrap() error {
 	return err.Base
 }
+
+type ErrNotFound struct {
+	Base error
+}
+
+func NewErrNotFound(base error) error {
+	return ErrNotFound{Base: base}
+}
+
+func (err ErrNotFound) Error() string {
+	return "NotFound: " + err.Base.Error()
+}
+
+func (err ErrNotFound) Unwrap() error {
+	return err.Base
+}
+
+type ErrAlreadyExists struct {
+	Base error
+}
+
+func NewErrAlreadyExists(base error) error {
+	return ErrAlreadyExists{Base: base}
+}
+
+func (err ErrAlreadyExists) Error() string {
+	return "AlreadyExists: " + err.Base.Error()
+}
+
+func (err ErrAlreadyExists) Unwrap() error {
+	return err.Base
+}
+
+type ErrPermissionDenied struct {
+	Base error
+}
+
+func NewErrPermissionDenied(base error) error {
+	return ErrPermissionDenied{Base: base}
+}
+
+func (err ErrPermissionDenied) Error() string {
+	return "PermissionDenied: " + err.Base.Error()
+}
+
+func (err ErrPermissionDenied) Unwrap() error {
+	return err.Base
+}
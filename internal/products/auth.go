@@ -0,0 +1,259 @@
+package products
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// Secret is either given inline and stored encrypted, or read from the file in the secrets dir on every fetch.
+// Inline values are never returned, Redacted is set instead.
+type Secret struct {
+	Value string
+	// name of the file relative to the secrets dir
+	File string
+	// inline value is stored, but not shown; on update such a secret keeps the stored value
+	Redacted bool
+}
+
+func (s Secret) empty() bool {
+	return s.Value == "" && s.File == "" && !s.Redacted
+}
+
+// kept tells whether the secret is sent back as it was shown.
+func (s Secret) kept() bool {
+	return s.Redacted && s.Value == "" && s.File == ""
+}
+
+// String keeps the value out of logs and error messages.
+func (s Secret) String() string {
+	if s.File != "" {
+		return "file:" + s.File
+	}
+	if s.Value != "" || s.Redacted {
+		return "[redacted]"
+	}
+	return ""
+}
+
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+func (s Secret) Validate() error {
+	if s.Value != "" && s.File != "" {
+		return fmt.Errorf("Validate: secret value and file are mutually exclusive")
+	}
+	if s.File != "" {
+		clean := filepath.Clean(s.File)
+		if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("Validate: secret file must be relative to the secrets dir, got: %s", s.File)
+		}
+	}
+	return nil
+}
+
+// redacted hides the inline value.
+func (s Secret) redacted() Secret {
+	if s.Value != "" {
+		return Secret{Redacted: true}
+	}
+	return s
+}
+
+// read returns the inline value or the content of the file with trailing newlines trimmed.
+func (s Secret) read(dir string) (string, error) {
+	if s.File == "" {
+		return s.Value, nil
+	}
+	if dir == "" {
+		return "", fmt.Errorf("read: secrets dir is not configured")
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, filepath.Clean(s.File)))
+	if err != nil {
+		return "", fmt.Errorf("read: secret file %s: %w", s.File, err)
+	}
+
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// SourceAuth is how the feed server tells it's us. Basic and bearer auth are mutually exclusive.
+type SourceAuth struct {
+	// basic auth if set
+	Username string
+	Password Secret
+	// bearer token
+	Token Secret
+	// e.g. X-Api-Key, set over the plain source headers
+	Headers map[string]Secret
+	// PEM encoded client certificate and its key
+	ClientCert Secret
+	ClientKey  Secret
+	// PEM encoded CAs the server certificate is checked against, system ones if empty
+	CA Secret
+}
+
+func (a SourceAuth) Validate() error {
+	if a.Username != "" && !a.Token.empty() {
+		return fmt.Errorf("Validate: basic auth and bearer token are mutually exclusive")
+	}
+	if a.Username == "" && !a.Password.empty() {
+		return fmt.Errorf("Validate: password without username")
+	}
+	if a.ClientCert.empty() != a.ClientKey.empty() {
+		return fmt.Errorf("Validate: client certificate and key go together")
+	}
+
+	for name, secret := range a.Headers {
+		if strings.TrimSpace(name) == "" || strings.ContainsAny(name, " :\r\n") {
+			return fmt.Errorf("Validate: invalid header name: %q", name)
+		}
+		if err := secret.Validate(); err != nil {
+			return fmt.Errorf("Validate: header %s: %w", name, err)
+		}
+	}
+	for name, secret := range map[string]Secret{
+		"password":    a.Password,
+		"token":       a.Token,
+		"client cert": a.ClientCert,
+		"client key":  a.ClientKey,
+		"ca":          a.CA,
+	} {
+		if err := secret.Validate(); err != nil {
+			return fmt.Errorf("Validate: %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// redacted hides every inline secret.
+func (a SourceAuth) redacted() SourceAuth {
+	a.Password = a.Password.redacted()
+	a.Token = a.Token.redacted()
+	a.ClientCert = a.ClientCert.redacted()
+	a.ClientKey = a.ClientKey.redacted()
+	a.CA = a.CA.redacted()
+	if a.Headers != nil {
+		headers := make(map[string]Secret, len(a.Headers))
+		for name, secret := range a.Headers {
+			headers[name] = secret.redacted()
+		}
+		a.Headers = headers
+	}
+	return a
+}
+
+// keep takes stored values of secrets sent back redacted.
+func (a SourceAuth) keep(stored SourceAuth) SourceAuth {
+	keep := func(s, stored Secret) Secret {
+		if s.kept() {
+			return stored
+		}
+		return s
+	}
+
+	a.Password = keep(a.Password, stored.Password)
+	a.Token = keep(a.Token, stored.Token)
+	a.ClientCert = keep(a.ClientCert, stored.ClientCert)
+	a.ClientKey = keep(a.ClientKey, stored.ClientKey)
+	a.CA = keep(a.CA, stored.CA)
+	if a.Headers != nil {
+		headers := make(map[string]Secret, len(a.Headers))
+		for name, secret := range a.Headers {
+			headers[name] = keep(secret, stored.Headers[name])
+		}
+		a.Headers = headers
+	}
+	return a
+}
+
+func (a SourceAuth) hasKept() bool {
+	for _, s := range []Secret{a.Password, a.Token, a.ClientCert, a.ClientKey, a.CA} {
+		if s.kept() {
+			return true
+		}
+	}
+	for _, s := range a.Headers {
+		if s.kept() {
+			return true
+		}
+	}
+	return false
+}
+
+// header returns the headers carrying credentials, secrets are read from files on every call.
+func (a SourceAuth) header(dir string) (http.Header, error) {
+	h := make(http.Header)
+
+	switch {
+	case a.Username != "":
+		password, err := a.Password.read(dir)
+		if err != nil {
+			return nil, fmt.Errorf("header: password: %w", err)
+		}
+		creds := base64.StdEncoding.EncodeToString([]byte(a.Username + ":" + password))
+		h.Set("Authorization", "Basic "+creds)
+	case !a.Token.empty():
+		token, err := a.Token.read(dir)
+		if err != nil {
+			return nil, fmt.Errorf("header: token: %w", err)
+		}
+		h.Set("Authorization", "Bearer "+token)
+	}
+
+	for name, secret := range a.Headers {
+		value, err := secret.read(dir)
+		if err != nil {
+			return nil, fmt.Errorf("header: %s: %w", name, err)
+		}
+		h.Set(name, value)
+	}
+
+	return h, nil
+}
+
+// tlsConfig returns nil if the source needs neither client certificate nor own CAs.
+func (a SourceAuth) tlsConfig(dir string) (*tls.Config, error) {
+	if a.ClientCert.empty() && a.CA.empty() {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{}
+
+	if !a.ClientCert.empty() {
+		certPEM, err := a.ClientCert.read(dir)
+		if err != nil {
+			return nil, fmt.Errorf("tlsConfig: client cert: %w", err)
+		}
+		keyPEM, err := a.ClientKey.read(dir)
+		if err != nil {
+			return nil, fmt.Errorf("tlsConfig: client key: %w", err)
+		}
+		cert, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("tlsConfig: client cert: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if !a.CA.empty() {
+		caPEM, err := a.CA.read(dir)
+		if err != nil {
+			return nil, fmt.Errorf("tlsConfig: ca: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caPEM)) {
+			return nil, fmt.Errorf("tlsConfig: ca: no certificates found")
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,153 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+)
+
+// FetchBatchItem is a single feed of the batch: url, or no url and WithSource among the options.
+type FetchBatchItem struct {
+	URL     string
+	Options []option
+}
+
+// FetchBatchResult tells how a single feed of the batch went, in the order of the items.
+type FetchBatchResult struct {
+	URL string
+	// empty if fetched by url
+	Source string
+	// started or joined job, the one in flight if rejected as a conflict; zero if no job was found or started
+	Job FetchJob
+	// why the job was not started
+	Err error
+}
+
+// FetchBatch starts the fetch job of every feed and returns without waiting for them,
+// the jobs are polled by GetFetchJob. Jobs are queued for the fetch workers as any other,
+// see ServiceConfig.FetchWorkers. Feeds failing to start don't stop the others,
+// every failure is reported by its result. Waiting for the job in flight is not supported,
+// feeds conflicting with one are joined or rejected.
+func (s *service) FetchBatch(ctx context.Context, items []FetchBatchItem) ([]FetchBatchResult, error) {
+	if len(items) == 0 {
+		return nil, errors.NewErrInvalidInput(fmt.Errorf("FetchBatch: no feeds given"))
+	}
+	if s.cfg.MaxBatchFeeds > 0 && len(items) > s.cfg.MaxBatchFeeds {
+		return nil, errors.NewErrInvalidInput(fmt.Errorf("FetchBatch: %d feeds given, at most %d allowed", len(items), s.cfg.MaxBatchFeeds))
+	}
+	for i, item := range items {
+		if applyOptions(item.Options).conflict == ConflictWait {
+			return nil, errors.NewErrInvalidInput(fmt.Errorf("FetchBatch: feed %d: waiting for the job in flight is not supported, join or reject it", i))
+		}
+	}
+
+	results := make([]FetchBatchResult, len(items))
+	for i, item := range items {
+		results[i] = s.fetchBatchItem(ctx, item)
+	}
+
+	return results, nil
+}
+
+func (s *service) fetchBatchItem(ctx context.Context, item FetchBatchItem) FetchBatchResult {
+	opts := append(append([]option{}, item.Options...), Options().WithWait(false))
+
+	var res FetchBatchResult
+	path, source, opts, err := s.resolveSource(ctx, item.URL, opts)
+	if err != nil {
+		res.URL, res.Err = item.URL, fmt.Errorf("fetchBatchItem: %w", err)
+		return res
+	}
+	res.URL, res.Source = path, source
+
+	res.Job, err = s.fetchResolved(ctx, path, source, opts)
+	if err != nil {
+		res.Err = fmt.Errorf("fetchBatchItem: %w", err)
+	}
+
+	return res
+}
+
+// fetchHost is what per host limit counts fetches by: scheme and host, bucket for s3 urls.
+func fetchHost(path string) string {
+	u, err := url.Parse(path)
+	if err != nil {
+		return path
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
+
+// fetchPool limits feeds downloaded at once by the service, in total and per host; zero means unlimited.
+type fetchPool struct {
+	workers chan struct{}
+	perHost int
+	mu      sync.Mutex
+	hosts   map[string]chan struct{}
+}
+
+func newFetchPool(workers, perHost int) *fetchPool {
+	p := &fetchPool{
+		perHost: perHost,
+		hosts:   make(map[string]chan struct{}),
+	}
+	if workers > 0 {
+		p.workers = make(chan struct{}, workers)
+	}
+	return p
+}
+
+// acquire takes the host slot first, so feeds waiting for a busy host don't hold workers others could use.
+func (p *fetchPool) acquire(ctx context.Context, host string) (release func(), err error) {
+	hostSlot := p.host(host)
+	if err := take(ctx, hostSlot); err != nil {
+		return nil, fmt.Errorf("acquire: %w", err)
+	}
+	if err := take(ctx, p.workers); err != nil {
+		give(hostSlot)
+		return nil, fmt.Errorf("acquire: %w", err)
+	}
+
+	return func() {
+		give(p.workers)
+		give(hostSlot)
+	}, nil
+}
+
+func (p *fetchPool) host(host string) chan struct{} {
+	if p.perHost <= 0 {
+		return nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	slot, ok := p.hosts[host]
+	if !ok {
+		slot = make(chan struct{}, p.perHost)
+		p.hosts[host] = slot
+	}
+	return slot
+}
+
+// take blocks until the semaphore has room, nil semaphore is unlimited.
+func take(ctx context.Context, sem chan struct{}) error {
+	if sem == nil {
+		return nil
+	}
+	select {
+	case sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func give(sem chan struct{}) {
+	if sem != nil {
+		<-sem
+	}
+}
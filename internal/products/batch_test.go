@@ -0,0 +1,135 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFetchHost(t *testing.T) {
+	tests := []struct {
+		path string
+		host string
+	}{
+		{path: "https://Feeds.example.com/a.csv", host: "https://feeds.example.com"},
+		{path: "https://feeds.example.com:8443/a.csv", host: "https://feeds.example.com:8443"},
+		{path: "http://feeds.example.com/a.csv", host: "http://feeds.example.com"},
+		{path: "s3://bucket/daily/", host: "s3://bucket"},
+		{path: "file:///srv/feeds/a.csv", host: "file://"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if host := fetchHost(tt.path); host != tt.host {
+				t.Errorf("fetchHost() = %s, want %s", host, tt.host)
+			}
+		})
+	}
+}
+
+func TestFetchPool(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers int
+		perHost int
+		// host of every fetch started at once
+		hosts []string
+		// most fetches seen running at once, in total and per host
+		maxTotal, maxPerHost int
+	}{
+		{name: "unlimited", hosts: []string{"a", "a", "a", "b", "b"}, maxTotal: 5, maxPerHost: 3},
+		// at most one of the pairs started together has b, two a run at once in another
+		{name: "workers", workers: 2, hosts: []string{"a", "a", "a", "a", "b"}, maxTotal: 2, maxPerHost: 2},
+		{name: "per host", perHost: 1, hosts: []string{"a", "a", "a", "b", "b"}, maxTotal: 2, maxPerHost: 1},
+		{name: "both", workers: 3, perHost: 2, hosts: []string{"a", "a", "a", "b"}, maxTotal: 3, maxPerHost: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newFetchPool(tt.workers, tt.perHost)
+
+			var (
+				mu            sync.Mutex
+				running       int
+				hosts         = make(map[string]int)
+				total, onHost int
+				wg            sync.WaitGroup
+			)
+			for _, host := range tt.hosts {
+				wg.Add(1)
+				go func(host string) {
+					defer wg.Done()
+
+					release, err := p.acquire(context.Background(), host)
+					if err != nil {
+						t.Errorf("acquire(%s): %v", host, err)
+						return
+					}
+					defer release()
+
+					mu.Lock()
+					running++
+					hosts[host]++
+					if running > total {
+						total = running
+					}
+					if hosts[host] > onHost {
+						onHost = hosts[host]
+					}
+					mu.Unlock()
+
+					time.Sleep(20 * time.Millisecond)
+
+					mu.Lock()
+					running--
+					hosts[host]--
+					mu.Unlock()
+				}(host)
+			}
+			wg.Wait()
+
+			if total != tt.maxTotal {
+				t.Errorf("fetches at once = %d, want %d", total, tt.maxTotal)
+			}
+			if onHost != tt.maxPerHost {
+				t.Errorf("fetches from a host at once = %d, want %d", onHost, tt.maxPerHost)
+			}
+		})
+	}
+}
+
+func TestFetchPoolCanceled(t *testing.T) {
+	p := newFetchPool(1, 1)
+
+	release, err := p.acquire(context.Background(), "a")
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	// waiting for the host and for a worker
+	for _, host := range []string{"a", "b"} {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := p.acquire(ctx, host)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("acquire(%s) error = %v, want %v", host, err, context.DeadlineExceeded)
+		}
+	}
+
+	release()
+
+	// slots taken by canceled waits are given back
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	release, err = p.acquire(ctx, "b")
+	if err != nil {
+		t.Fatalf("acquire(b) after release: %v", err)
+	}
+	release()
+	if release, err = p.acquire(ctx, "a"); err != nil {
+		t.Fatalf("acquire(a) after release: %v", err)
+	}
+	release()
+}
@@ -0,0 +1,141 @@
+package products
+
+import (
+	goErrors "errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// BreakerPolicy stops requests to the host failing again and again, until it had time to recover.
+type BreakerPolicy struct {
+	// consecutive downloads failed with transient errors after every retry opening the breaker,
+	// zero disables the breaker
+	Failures int
+	// how long the open breaker rejects requests before it lets a single trial one through
+	Cooldown time.Duration
+}
+
+var errBreakerOpen = goErrors.New("circuit breaker is open")
+
+var (
+	// requests, retries, failures, breakerOpened, breakerRejected
+	fetchMetrics = expvar.NewMap("fetch")
+	// state of breaker per host
+	breakerMetrics = expvar.NewMap("fetchBreakers")
+)
+
+type breakerOutcome int
+
+const (
+	// download canceled, nothing learned about the host
+	outcomeNone breakerOutcome = iota
+	outcomeSuccess
+	outcomeFailure
+)
+
+type breaker struct {
+	state    BreakerState
+	failures int
+	until    time.Time
+	// half-open breaker lets a single request through
+	trial bool
+}
+
+// breakers are kept per host by the instance.
+type breakers struct {
+	policy BreakerPolicy
+	mu     sync.Mutex
+	hosts  map[string]*breaker
+}
+
+func newBreakers(policy BreakerPolicy) *breakers {
+	return &breakers{
+		policy: policy,
+		hosts:  make(map[string]*breaker),
+	}
+}
+
+func (bs *breakers) get(host string) *breaker {
+	b, ok := bs.hosts[host]
+	if !ok {
+		b = &breaker{state: BreakerClosed}
+		bs.hosts[host] = b
+	}
+	return b
+}
+
+// allow tells whether the host may be requested now.
+func (bs *breakers) allow(host string, now time.Time) (BreakerState, error) {
+	if bs.policy.Failures <= 0 {
+		return BreakerClosed, nil
+	}
+
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	b := bs.get(host)
+	switch {
+	case b.state == BreakerOpen && now.Before(b.until):
+	case b.state == BreakerOpen:
+		bs.set(host, b, BreakerHalfOpen)
+		b.trial = true
+		return b.state, nil
+	case b.state == BreakerHalfOpen && b.trial:
+	case b.state == BreakerHalfOpen:
+		b.trial = true
+		return b.state, nil
+	default:
+		return b.state, nil
+	}
+
+	fetchMetrics.Add("breakerRejected", 1)
+	return b.state, fmt.Errorf("allow: host %s: %w until %s", host, errBreakerOpen, b.until.UTC().Format(time.RFC3339))
+}
+
+// record moves the breaker by the outcome of the allowed request and returns its new state.
+func (bs *breakers) record(host string, outcome breakerOutcome, now time.Time) BreakerState {
+	if bs.policy.Failures <= 0 {
+		return BreakerClosed
+	}
+
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	b := bs.get(host)
+	b.trial = false
+
+	switch outcome {
+	case outcomeSuccess:
+		b.failures = 0
+		bs.set(host, b, BreakerClosed)
+	case outcomeFailure:
+		fetchMetrics.Add("failures", 1)
+		b.failures++
+		if b.state == BreakerHalfOpen || b.failures >= bs.policy.Failures {
+			b.until = now.Add(bs.policy.Cooldown)
+			if b.state != BreakerOpen {
+				fetchMetrics.Add("breakerOpened", 1)
+			}
+			bs.set(host, b, BreakerOpen)
+		}
+	}
+
+	return b.state
+}
+
+func (bs *breakers) set(host string, b *breaker, state BreakerState) {
+	b.state = state
+	s := new(expvar.String)
+	s.Set(string(state))
+	breakerMetrics.Set(host, s)
+}
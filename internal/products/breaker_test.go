@@ -0,0 +1,144 @@
+package products
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreakers(t *testing.T) {
+	const (
+		host     = "feeds.example.com"
+		cooldown = time.Minute
+	)
+	start := time.Date(2021, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	type step struct {
+		// seconds since start
+		at int
+		// allow the request, then record the outcome if allowed
+		outcome breakerOutcome
+		// state returned by allow, the request rejected if rejected is set
+		allowed  BreakerState
+		rejected bool
+		// state after the outcome is recorded
+		state BreakerState
+	}
+
+	tests := []struct {
+		name     string
+		failures int
+		steps    []step
+	}{
+		{
+			name:     "disabled",
+			failures: 0,
+			steps: []step{
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerClosed},
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerClosed},
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerClosed},
+			},
+		},
+		{
+			name:     "opened by failures in a row",
+			failures: 3,
+			steps: []step{
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerClosed},
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerClosed},
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerOpen},
+				{at: 30, allowed: BreakerOpen, rejected: true},
+			},
+		},
+		{
+			name:     "success resets failures",
+			failures: 2,
+			steps: []step{
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerClosed},
+				{outcome: outcomeSuccess, allowed: BreakerClosed, state: BreakerClosed},
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerClosed},
+				{outcome: outcomeSuccess, allowed: BreakerClosed, state: BreakerClosed},
+			},
+		},
+		{
+			name:     "canceled downloads ignored",
+			failures: 2,
+			steps: []step{
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerClosed},
+				{outcome: outcomeNone, allowed: BreakerClosed, state: BreakerClosed},
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerOpen},
+			},
+		},
+		{
+			name:     "trial success closes",
+			failures: 1,
+			steps: []step{
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerOpen},
+				{at: 59, allowed: BreakerOpen, rejected: true},
+				{at: 60, outcome: outcomeSuccess, allowed: BreakerHalfOpen, state: BreakerClosed},
+				{at: 61, outcome: outcomeSuccess, allowed: BreakerClosed, state: BreakerClosed},
+			},
+		},
+		{
+			name:     "trial failure opens again",
+			failures: 3,
+			steps: []step{
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerClosed},
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerClosed},
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerOpen},
+				{at: 60, outcome: outcomeFailure, allowed: BreakerHalfOpen, state: BreakerOpen},
+				{at: 100, allowed: BreakerOpen, rejected: true},
+				{at: 120, outcome: outcomeSuccess, allowed: BreakerHalfOpen, state: BreakerClosed},
+			},
+		},
+		{
+			name:     "canceled trial lets another one through",
+			failures: 1,
+			steps: []step{
+				{outcome: outcomeFailure, allowed: BreakerClosed, state: BreakerOpen},
+				{at: 60, outcome: outcomeNone, allowed: BreakerHalfOpen, state: BreakerHalfOpen},
+				{at: 61, outcome: outcomeSuccess, allowed: BreakerHalfOpen, state: BreakerClosed},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs := newBreakers(BreakerPolicy{Failures: tt.failures, Cooldown: cooldown})
+			for i, s := range tt.steps {
+				now := start.Add(time.Duration(s.at) * time.Second)
+				state, err := bs.allow(host, now)
+				if state != s.allowed || (err != nil) != s.rejected {
+					t.Fatalf("step %d: allow() = %s, %v, want %s, rejected %v", i, state, err, s.allowed, s.rejected)
+				}
+				if err != nil {
+					if !errors.Is(err, errBreakerOpen) {
+						t.Fatalf("step %d: allow() error = %v, want %v", i, err, errBreakerOpen)
+					}
+					continue
+				}
+				if state := bs.record(host, s.outcome, now); state != s.state {
+					t.Fatalf("step %d: record() = %s, want %s", i, state, s.state)
+				}
+			}
+		})
+	}
+}
+
+func TestBreakersSingleTrial(t *testing.T) {
+	bs := newBreakers(BreakerPolicy{Failures: 1, Cooldown: time.Minute})
+	now := time.Date(2021, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	bs.allow("a", now)
+	bs.record("a", outcomeFailure, now)
+
+	now = now.Add(time.Minute)
+	if _, err := bs.allow("a", now); err != nil {
+		t.Fatalf("trial allow() error = %v", err)
+	}
+	if state, err := bs.allow("a", now); state != BreakerHalfOpen || err == nil {
+		t.Errorf("second allow() during trial = %s, %v, want rejected", state, err)
+	}
+	if _, err := bs.allow("b", now); err != nil {
+		t.Errorf("other host allow() error = %v", err)
+	}
+}
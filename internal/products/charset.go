@@ -0,0 +1,83 @@
+package products
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+const charsetUTF8 = "utf-8"
+
+var boms = []struct {
+	bom []byte
+	enc encoding.Encoding
+}{
+	{[]byte{0xef, 0xbb, 0xbf}, unicode.UTF8},
+	{[]byte{0xff, 0xfe}, unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM)},
+	{[]byte{0xfe, 0xff}, unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM)},
+}
+
+// lookupCharset accepts WHATWG encoding labels, e.g. windows-1251, cp1251, koi8-r.
+func lookupCharset(label string) (encoding.Encoding, error) {
+	enc, err := htmlindex.Get(label)
+	if err != nil {
+		return nil, fmt.Errorf("lookupCharset: unsupported charset: %s", label)
+	}
+	return enc, nil
+}
+
+// toUTF8 transcodes the feed to utf-8 and strips BOM. BOM wins over explicit charset,
+// which wins over content type charset parameter. Feed is passed as is if charset is unknown,
+// known tells whether the result is utf-8 for sure.
+func toUTF8(br *bufio.Reader, explicit, contentType string) (r io.Reader, known bool, err error) {
+	peeked, _ := br.Peek(3)
+	for _, b := range boms {
+		if bytes.HasPrefix(peeked, b.bom) {
+			if _, err := br.Discard(len(b.bom)); err != nil {
+				return nil, false, fmt.Errorf("toUTF8: %w", err)
+			}
+			return decodeCharset(br, b.enc), true, nil
+		}
+	}
+
+	label := explicit
+	if label == "" {
+		if _, params, err := mime.ParseMediaType(contentType); err == nil {
+			label = params["charset"]
+		}
+	}
+	if label == "" {
+		return br, false, nil
+	}
+
+	enc, err := lookupCharset(label)
+	if err != nil {
+		return nil, false, fmt.Errorf("toUTF8: %w", err)
+	}
+
+	return decodeCharset(br, enc), true, nil
+}
+
+// decodeCharset keeps utf-8 untouched, so invalid sequences are rejected instead of being replaced.
+func decodeCharset(r io.Reader, enc encoding.Encoding) io.Reader {
+	if name, _ := htmlindex.Name(enc); name == charsetUTF8 {
+		return r
+	}
+	return transform.NewReader(r, enc.NewDecoder())
+}
+
+// xmlCharsetReader decodes charset declared by the xml document itself.
+func xmlCharsetReader(label string, input io.Reader) (io.Reader, error) {
+	enc, err := lookupCharset(label)
+	if err != nil {
+		return nil, fmt.Errorf("xmlCharsetReader: %w", err)
+	}
+	return decodeCharset(input, enc), nil
+}
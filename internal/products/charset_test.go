@@ -0,0 +1,138 @@
+package products
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+func TestToUTF8(t *testing.T) {
+	cp1251 := mustEncode(t, charmap.Windows1251.NewEncoder().Bytes, "цена")
+	utf16le := mustEncode(t, unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().Bytes, "цена")
+	utf16be := mustEncode(t, unicode.UTF16(unicode.BigEndian, unicode.UseBOM).NewEncoder().Bytes, "цена")
+
+	tests := []struct {
+		name        string
+		feed        []byte
+		explicit    string
+		contentType string
+		out         []byte
+		known       bool
+		wantErr     bool
+	}{
+		{name: "utf-8 bom", feed: []byte("\xef\xbb\xbfцена"), out: []byte("цена"), known: true},
+		{name: "utf-16le bom", feed: utf16le, out: []byte("цена"), known: true},
+		{name: "utf-16be bom", feed: utf16be, out: []byte("цена"), known: true},
+		{name: "bom over explicit", feed: []byte("\xef\xbb\xbfцена"), explicit: "windows-1251", out: []byte("цена"), known: true},
+		{name: "explicit", feed: cp1251, explicit: "cp1251", out: []byte("цена"), known: true},
+		{name: "explicit over content type", feed: cp1251, explicit: "windows-1251", contentType: "text/csv; charset=koi8-r", out: []byte("цена"), known: true},
+		{name: "content type", feed: cp1251, contentType: "text/csv; charset=windows-1251", out: []byte("цена"), known: true},
+		{name: "utf-8 kept as is", feed: []byte("цена\xff"), contentType: "text/csv; charset=utf-8", out: []byte("цена\xff"), known: true},
+		{name: "unknown", feed: cp1251, contentType: "text/csv", out: cp1251},
+		{name: "short feed", feed: []byte("a"), out: []byte("a")},
+		{name: "unsupported", feed: cp1251, explicit: "x-unknown", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, known, err := toUTF8(bufio.NewReader(bytes.NewReader(tt.feed)), tt.explicit, tt.contentType)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("toUTF8() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			out, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(out, tt.out) || known != tt.known {
+				t.Errorf("toUTF8() = %q, %v, want %q, %v", out, known, tt.out, tt.known)
+			}
+		})
+	}
+}
+
+func TestDecodeFeedCharset(t *testing.T) {
+	cp1251 := func(s string) string {
+		return string(mustEncode(t, charmap.Windows1251.NewEncoder().Bytes, s))
+	}
+
+	tests := []struct {
+		name    string
+		meta    feedMeta
+		schema  FeedSchema
+		feed    string
+		rows    []string
+		wantErr bool
+	}{
+		{
+			name: "csv of content type charset",
+			meta: feedMeta{Name: "feed.csv", ContentType: "text/csv; charset=windows-1251"},
+			feed: cp1251("наименование;цена\nчай;1\n"),
+			rows: []string{"2 чай 1"},
+		},
+		{
+			name: "csv of unknown charset",
+			meta: feedMeta{Name: "feed.csv"},
+			feed: cp1251("name;price\nчай;1\n"),
+			rows: []string{"2 error"},
+		},
+		{
+			name: "xml declaration",
+			meta: feedMeta{Name: "feed.xml"},
+			feed: `<?xml version="1.0" encoding="windows-1251"?>` + cp1251("<offers><offer><name>чай</name><price>1</price></offer></offers>"),
+			rows: []string{"1 чай 1"},
+		},
+		{
+			name: "bom over xml declaration",
+			meta: feedMeta{Name: "feed.xml"},
+			feed: "\xef\xbb\xbf" + `<?xml version="1.0" encoding="windows-1251"?><offers><offer><name>чай</name><price>1</price></offer></offers>`,
+			rows: []string{"1 чай 1"},
+		},
+		{
+			name:   "explicit charset over xml declaration",
+			meta:   feedMeta{Name: "feed.xml"},
+			schema: FeedSchema{Charset: "windows-1251"},
+			feed:   `<?xml version="1.0" encoding="windows-1251"?>` + cp1251("<offers><offer><name>чай</name><price>1</price></offer></offers>"),
+			rows:   []string{"1 чай 1"},
+		},
+		{
+			name:    "xml declaration of unsupported charset",
+			meta:    feedMeta{Name: "feed.xml"},
+			feed:    `<?xml version="1.0" encoding="x-unknown"?><offers><offer><name>a</name><price>1</price></offer></offers>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []string
+			err := decodeFeed(strings.NewReader(tt.feed), tt.meta, UnpackLimits{}, &optsHolder{schema: tt.schema}, func(row FeedRow) error {
+				rows = append(rows, rowString(row))
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeFeed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("rows = %q, want %q", rows, tt.rows)
+			}
+		})
+	}
+}
+
+func mustEncode(t *testing.T, encode func([]byte) ([]byte, error), s string) []byte {
+	t.Helper()
+	b, err := encode([]byte(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
@@ -2,94 +2,414 @@ package products
 
 import (
 	"context"
-	"encoding/csv"
+	"crypto/sha256"
+	"encoding/hex"
+	goErrors "errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"strings"
 	"time"
 
-	"github.com/shopspring/decimal"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/marknovikov/products-demo/internal/errors"
 )
 
 type Client interface {
-	List(ctx context.Context, url string) ([]Product, error)
+	// List streams feed rows to fn in feed order, stops on the first error returned by fn.
+	// Given the version of the last fetch, nothing is streamed and errFeedNotModified or errFeedUnchanged
+	// is returned if the feed is the same.
+	List(ctx context.Context, url string, fn func(row FeedRow) error, opts ...option) (ListResult, error)
+	// CheckURL tells whether the url may be fetched at all, before any job is started.
+	CheckURL(url string) error
+	// Decode parses the feed given as is the way List parses the downloaded one,
+	// name tells format and compression by its extension unless detected otherwise.
+	Decode(name string, body io.Reader, fn func(row FeedRow) error, opts ...option) error
+}
+
+var (
+	// server replied 304 Not Modified
+	errFeedNotModified = goErrors.New("feed not modified")
+	// body hash is the same as the last time
+	errFeedUnchanged = goErrors.New("feed content unchanged")
+)
+
+// ListResult tells how the feed was downloaded, filled in on errors as well.
+type ListResult struct {
+	Version FeedVersion
+	// requests repeated after transient failures
+	Retries uint32
+	// state of the feed host breaker after the download
+	Breaker BreakerState
 }
 
 type ClientConfig struct {
+	// limits waiting for response headers and for every single read of the body,
+	// big feeds may take much longer to download as a whole
 	HttpTimeout time.Duration
+	// limits for compressed and archived feeds
+	Unpack UnpackLimits
+	// where feeds may be fetched from
+	Egress EgressPolicy
+	// where secret files of sources are read from
+	SecretsDir string
+	// directories file:// feeds may be read from, file feeds are off if empty
+	FileRoots []string
+	// where s3:// feeds are fetched from
+	S3      S3Config
+	Retry   RetryPolicy
+	Breaker BreakerPolicy
 }
 
 type httpClient struct {
-	cli *http.Client
-	cfg ClientConfig
+	transport *http.Transport
+	cfg       ClientConfig
+	egress    *egress
+	breakers  *breakers
+	files     fileRoots
+	s3        *session.Session
 }
 
-func NewClient(cfg ClientConfig) Client {
-	cli := &http.Client{
-		Timeout: cfg.HttpTimeout,
+func NewClient(cfg ClientConfig) (Client, error) {
+	egress, err := newEgress(cfg.Egress)
+	if err != nil {
+		return nil, fmt.Errorf("NewClient: %w", err)
+	}
+	files, err := newFileRoots(cfg.FileRoots)
+	if err != nil {
+		return nil, fmt.Errorf("NewClient: %w", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = cfg.HttpTimeout
+	// proxy would hide the real address of the feed from the egress policy
+	transport.Proxy = nil
+	transport.DialContext = egress.dialContext(&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	})
+
+	s3, err := newS3Session(cfg.S3, transport, cfg.Retry)
+	if err != nil {
+		return nil, fmt.Errorf("NewClient: %w", err)
 	}
 
 	return &httpClient{
-		cli,
+		transport,
 		cfg,
+		egress,
+		newBreakers(cfg.Breaker),
+		files,
+		s3,
+	}, nil
+}
+
+// client returns the client presenting the source credentials, close releases its own connections if any.
+func (c *httpClient) client(auth SourceAuth, authHeader http.Header) (*http.Client, func(), error) {
+	tlsCfg, err := auth.tlsConfig(c.cfg.SecretsDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("client: %w", err)
 	}
+
+	transport, closeTransport := c.transport, func() {}
+	if tlsCfg != nil {
+		transport = c.transport.Clone()
+		transport.TLSClientConfig = tlsCfg
+		closeTransport = transport.CloseIdleConnections
+	}
+
+	cli := &http.Client{
+		Transport:     transport,
+		CheckRedirect: c.checkRedirect(authHeader),
+	}
+
+	return cli, closeTransport, nil
 }
 
-func csvRowToProduct(row []string) (Product, error) {
-	if len(row) < 2 {
-		return Product{}, fmt.Errorf("csvRowToProduct: row: %v, expected exactly 2 cols per row, got: %d", row, len(row))
+func (c *httpClient) checkRedirect(authHeader http.Header) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > c.cfg.Egress.MaxRedirects {
+			return errors.NewErrPermissionDenied(fmt.Errorf("CheckRedirect: stopped after %d redirects", c.cfg.Egress.MaxRedirects))
+		}
+		if err := c.egress.checkURL(req.URL); err != nil {
+			return fmt.Errorf("CheckRedirect: %w", err)
+		}
+		// credentials are not handed over to other hosts
+		if req.URL.Host != via[0].URL.Host {
+			for name := range authHeader {
+				req.Header.Del(name)
+			}
+		}
+		return nil
 	}
+}
 
-	price, err := decimal.NewFromString(row[1])
+func (c *httpClient) CheckURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
 	if err != nil {
-		return Product{}, fmt.Errorf("csvRowToProduct: %w", err)
+		return errors.NewErrInvalidInput(fmt.Errorf("CheckURL: %w", err))
+	}
+	if isFileURL(u) {
+		if _, err := c.files.checkURL(u); err != nil {
+			return fmt.Errorf("CheckURL: %w", err)
+		}
+		return nil
+	}
+	if isS3URL(u) {
+		if err := c.egress.checkScheme(u); err != nil {
+			return fmt.Errorf("CheckURL: %w", err)
+		}
+		if _, _, _, err := s3Location(u); err != nil {
+			return fmt.Errorf("CheckURL: %w", err)
+		}
+		return nil
+	}
+	if err := c.egress.checkURL(u); err != nil {
+		return fmt.Errorf("CheckURL: %w", err)
 	}
 
-	return Product{
-		Name:  row[0],
-		Price: price,
-	}, nil
+	return nil
 }
 
-func (c *httpClient) List(ctx context.Context, url string) ([]Product, error) {
+func (c *httpClient) List(ctx context.Context, url string, fn func(row FeedRow) error, opts ...option) (ListResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	res := ListResult{Breaker: BreakerClosed}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("List: %w", err)
+		return res, fmt.Errorf("List: %w", err)
+	}
+	optsHolder := applyOptions(opts)
+	prev := optsHolder.version
+
+	if isFileURL(req.URL) {
+		if err := c.listFile(ctx, req.URL, &res, optsHolder, fn); err != nil {
+			return res, fmt.Errorf("List: %w", err)
+		}
+		return res, nil
+	}
+
+	name := url
+	var authHeader http.Header
+	if isS3URL(req.URL) {
+		// signed with the source credentials, nothing may be added
+		if req, name, err = c.s3Request(ctx, req.URL, optsHolder); err != nil {
+			return res, fmt.Errorf("List: %w", err)
+		}
+	} else {
+		req.Header.Set("Accept", acceptHeader(optsHolder.format))
+		// set explicitly, so the transport does not decompress gzip on its own
+		req.Header.Set("Accept-Encoding", "gzip, zstd")
+		req.Header.Set("Connection", "Keep-Alive")
+		for name, value := range optsHolder.headers {
+			req.Header.Set(name, value)
+		}
+		if prev.ETag != "" {
+			req.Header.Set("If-None-Match", prev.ETag)
+		}
+		if prev.LastModified != "" {
+			req.Header.Set("If-Modified-Since", prev.LastModified)
+		}
+
+		if authHeader, err = optsHolder.auth.header(c.cfg.SecretsDir); err != nil {
+			return res, fmt.Errorf("List: %w", err)
+		}
+		for name, values := range authHeader {
+			req.Header[name] = values
+		}
+	}
+
+	cli, closeCli, err := c.client(optsHolder.auth, authHeader)
+	if err != nil {
+		return res, fmt.Errorf("List: %w", err)
+	}
+	defer closeCli()
+
+	host := req.URL.Host
+	if res.Breaker, err = c.breakers.allow(host, time.Now()); err != nil {
+		return res, fmt.Errorf("List: %w", err)
 	}
-	req.Header.Set("Accept", "text/csv; charset=utf-8")
-	req.Header.Set("Connection", "Keep-Alive")
 
-	resp, err := c.cli.Do(req)
+	resp, err := c.do(ctx, cli, req, &res)
+	outcome := outcomeSuccess
+	switch {
+	case ctx.Err() != nil:
+		outcome = outcomeNone
+	case transientFailure(resp, err):
+		outcome = outcomeFailure
+	}
+	res.Breaker = c.breakers.record(host, outcome, time.Now())
 	if err != nil {
-		return nil, fmt.Errorf("List: %w", err)
+		return res, fmt.Errorf("List: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && (prev.ETag != "" || prev.LastModified != "") {
+		res.Version = prev
+		return res, fmt.Errorf("List: %w", errFeedNotModified)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("List: 200 http status expected, got: %s", resp.Status)
+		return res, fmt.Errorf("List: 200 http status expected, got: %s", resp.Status)
+	}
+	maxSize := c.cfg.Egress.MaxResponseSize
+	if maxSize > 0 && resp.ContentLength > maxSize {
+		return res, errors.NewErrPermissionDenied(fmt.Errorf("List: response is larger than %d bytes", maxSize))
 	}
 
-	r := csv.NewReader(resp.Body)
-	r.Comma = ';'
+	res.Version = FeedVersion{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	meta := feedMeta{
+		Name:            name,
+		ContentType:     resp.Header.Get("Content-Type"),
+		ContentEncoding: resp.Header.Get("Content-Encoding"),
+	}
 
-	// skip head
-	_, _ = r.Read()
+	var body io.Reader = newReadTimeoutReader(resp.Body, c.cfg.HttpTimeout, cancel)
+	if maxSize > 0 {
+		body = &responseLimitReader{r: body, max: maxSize}
+	}
 
-	var pp []Product
-	for {
-		row, err := r.Read()
-		if err == io.EOF {
-			return pp, nil
-		}
+	if err := c.decode(body, meta, &res.Version, optsHolder, fn); err != nil {
+		return res, fmt.Errorf("List: %w", err)
+	}
+
+	return res, nil
+}
+
+// listFile reads the feed from the local file, the version is told by its size and modification time.
+func (c *httpClient) listFile(ctx context.Context, u *url.URL, res *ListResult, optsHolder *optsHolder, fn func(row FeedRow) error) error {
+	f, err := c.files.open(u)
+	if err != nil {
+		return fmt.Errorf("listFile: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("listFile: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return errors.NewErrInvalidInput(fmt.Errorf("listFile: %s is not a regular file", f.Name()))
+	}
+
+	prev := optsHolder.version
+	res.Version = FeedVersion{
+		ETag:         fmt.Sprintf(`"%x-%x"`, info.Size(), info.ModTime().UnixNano()),
+		LastModified: info.ModTime().UTC().Format(http.TimeFormat),
+	}
+	if prev.ETag != "" && prev.ETag == res.Version.ETag {
+		res.Version = prev
+		return fmt.Errorf("listFile: %w", errFeedNotModified)
+	}
+
+	if err := c.decode(contextReader{ctx, f}, feedMeta{Name: u.String()}, &res.Version, optsHolder, fn); err != nil {
+		return fmt.Errorf("listFile: %w", err)
+	}
+
+	return nil
+}
+
+// decode parses the body and fills in its hash, nothing is parsed if the hash is the same as the last time.
+func (c *httpClient) decode(body io.Reader, meta feedMeta, version *FeedVersion, optsHolder *optsHolder, fn func(row FeedRow) error) error {
+	prev := optsHolder.version
+
+	hash := sha256.New()
+	body = io.TeeReader(body, hash)
+
+	if prev.SHA256 != "" {
+		// the whole body is hashed before anything is streamed, so unchanged feed is not written at all
+		tmp, _, err := spool(body, "feed-*", c.cfg.Unpack.MaxSize)
 		if err != nil {
-			return pp, fmt.Errorf("List: %w", err)
+			return fmt.Errorf("decode: %w", err)
 		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
 
-		p, err := csvRowToProduct(row)
-		if err != nil {
-			return pp, fmt.Errorf("List: %w", err)
+		version.SHA256 = hex.EncodeToString(hash.Sum(nil))
+		if version.SHA256 == prev.SHA256 {
+			return fmt.Errorf("decode: %w", errFeedUnchanged)
 		}
+		body = tmp
+	}
+
+	if err := decodeFeed(body, meta, c.cfg.Unpack, optsHolder, fn); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+
+	if version.SHA256 == "" {
+		// decoders may stop short of the body end
+		if _, err := io.Copy(ioutil.Discard, body); err != nil {
+			return fmt.Errorf("decode: %w", err)
+		}
+		version.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	}
+
+	return nil
+}
+
+func (c *httpClient) Decode(name string, body io.Reader, fn func(row FeedRow) error, opts ...option) error {
+	if err := decodeFeed(body, feedMeta{Name: name}, c.cfg.Unpack, applyOptions(opts), fn); err != nil {
+		return fmt.Errorf("Decode: %w", err)
+	}
+
+	return nil
+}
 
-		pp = append(pp, p)
+func isFileURL(u *url.URL) bool {
+	return strings.EqualFold(u.Scheme, "file")
+}
+
+func acceptHeader(format FeedFormat) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatJSON:
+		return "application/json"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatXML:
+		return "application/xml, text/xml"
+	default:
+		return feedAccept
+	}
+}
+
+// readTimeoutReader calls onTimeout if a single read from the underlying reader takes too long.
+// Time spent between reads does not count, so slow consumer does not trigger the timeout.
+type readTimeoutReader struct {
+	r       io.Reader
+	timeout time.Duration
+	timer   *time.Timer
+}
+
+func newReadTimeoutReader(r io.Reader, timeout time.Duration, onTimeout func()) *readTimeoutReader {
+	rtr := &readTimeoutReader{
+		r:       r,
+		timeout: timeout,
 	}
+	if timeout > 0 {
+		rtr.timer = time.AfterFunc(timeout, onTimeout)
+		rtr.timer.Stop()
+	}
+	return rtr
+}
+
+func (r *readTimeoutReader) Read(p []byte) (int, error) {
+	if r.timer == nil {
+		return r.r.Read(p)
+	}
+
+	r.timer.Reset(r.timeout)
+	defer r.timer.Stop()
+
+	return r.r.Read(p)
 }
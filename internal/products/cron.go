@@ -0,0 +1,189 @@
+package products
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a standard 5 field cron expression: minute hour day-of-month month day-of-week.
+// Fields hold bit sets of matching values.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// day matches if both day fields match when either is a star, or if any of them matches otherwise
+	domStar, dowStar bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is Sunday as well as 0
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronHorizon bounds the search for the next run, schedules like 0 0 30 2 * never run.
+const cronHorizon = 5 * 366 * 24 * time.Hour
+
+func parseCron(expr string) (cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return cronSchedule{}, fmt.Errorf("parseCron: 5 fields expected, got %d: %q", len(fields), expr)
+	}
+
+	var (
+		c   cronSchedule
+		err error
+	)
+	if c.minute, err = cronMinute.parse(fields[0]); err != nil {
+		return c, fmt.Errorf("parseCron: %w", err)
+	}
+	if c.hour, err = cronHour.parse(fields[1]); err != nil {
+		return c, fmt.Errorf("parseCron: %w", err)
+	}
+	if c.dom, err = cronDom.parse(fields[2]); err != nil {
+		return c, fmt.Errorf("parseCron: %w", err)
+	}
+	if c.month, err = cronMonth.parse(fields[3]); err != nil {
+		return c, fmt.Errorf("parseCron: %w", err)
+	}
+	if c.dow, err = cronDow.parse(fields[4]); err != nil {
+		return c, fmt.Errorf("parseCron: %w", err)
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domStar = strings.HasPrefix(fields[2], "*")
+	c.dowStar = strings.HasPrefix(fields[4], "*")
+
+	if c.next(time.Now()).IsZero() {
+		return c, fmt.Errorf("parseCron: %q never runs", expr)
+	}
+
+	return c, nil
+}
+
+// parse handles lists of values, ranges and steps: 5, 1-5, */15, 10-50/10, mon-fri.
+func (f cronField) parse(s string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(s, ",") {
+		rng, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("parse: %s: invalid step: %q", f.name, part)
+			}
+			rng, step = part[:i], n
+		}
+
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, fmt.Errorf("parse: %w", err)
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = f.value(bounds[1]); err != nil {
+					return 0, fmt.Errorf("parse: %w", err)
+				}
+			} else if step > 1 {
+				// 5/10 means 5-max/10
+				hi = f.max
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("parse: %s: invalid range: %q", f.name, part)
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("value: %s: invalid value: %q", f.name, s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value: %s: %d out of range %d-%d", f.name, v, f.min, f.max)
+	}
+
+	return v, nil
+}
+
+// next returns the first matching minute after t in UTC, zero time if there is none within cronHorizon.
+func (c cronSchedule) next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronHorizon)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (c cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
@@ -0,0 +1,90 @@
+package products
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{expr: "* * * * *"},
+		{expr: " 0 0 * * * "},
+		{expr: "@daily"},
+		{expr: "@HOURLY"},
+		{expr: "0,30 8-18/2 1-15 jan-jun mon-fri"},
+		{expr: "0 0 29 2 *"},
+		{expr: "0 0 * * 7"},
+		{expr: "", wantErr: true},
+		{expr: "* * * *", wantErr: true},
+		{expr: "* * * * * *", wantErr: true},
+		{expr: "@reboot", wantErr: true},
+		{expr: "60 * * * *", wantErr: true},
+		{expr: "* 24 * * *", wantErr: true},
+		{expr: "* * 0 * *", wantErr: true},
+		{expr: "* * * 13 *", wantErr: true},
+		{expr: "* * * * 8", wantErr: true},
+		{expr: "5-1 * * * *", wantErr: true},
+		{expr: "*/0 * * * *", wantErr: true},
+		{expr: "*/x * * * *", wantErr: true},
+		{expr: "a * * * *", wantErr: true},
+		{expr: "* * * foo *", wantErr: true},
+		{expr: "0 0 30 2 *", wantErr: true},
+		{expr: "0 0 31 4,6,9,11 *", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			if _, err := parseCron(tt.expr); (err != nil) != tt.wantErr {
+				t.Errorf("parseCron(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// Friday
+	from := time.Date(2021, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		from time.Time
+		next time.Time
+	}{
+		{expr: "* * * * *", next: time.Date(2021, 1, 15, 10, 31, 0, 0, time.UTC)},
+		{expr: "* * * * *", from: from.Add(30 * time.Second), next: time.Date(2021, 1, 15, 10, 31, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", next: time.Date(2021, 1, 15, 10, 45, 0, 0, time.UTC)},
+		{expr: "5/20 * * * *", next: time.Date(2021, 1, 15, 10, 45, 0, 0, time.UTC)},
+		{expr: "10-50/20 * * * *", next: time.Date(2021, 1, 15, 10, 50, 0, 0, time.UTC)},
+		{expr: "@hourly", next: time.Date(2021, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{expr: "30 10 * * *", next: time.Date(2021, 1, 16, 10, 30, 0, 0, time.UTC)},
+		{expr: "0 0 1,15 * *", next: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 1 *", next: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 12 * jun *", next: time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)},
+		{expr: "@weekly", next: time.Date(2021, 1, 17, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * 7", next: time.Date(2021, 1, 17, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * mon-fri", next: time.Date(2021, 1, 18, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * fri", next: time.Date(2021, 1, 22, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 20 * fri", next: time.Date(2021, 1, 20, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 2 *", next: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * *", from: time.Date(2021, 1, 15, 10, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60)), next: time.Date(2021, 1, 16, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			c, err := parseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("parseCron(%q): %v", tt.expr, err)
+			}
+			start := tt.from
+			if start.IsZero() {
+				start = from
+			}
+			if next := c.next(start); !next.Equal(tt.next) {
+				t.Errorf("next(%s) = %s, want %s", start, next, tt.next)
+			}
+		})
+	}
+}
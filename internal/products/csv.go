@@ -0,0 +1,416 @@
+package products
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	goErrors "errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type QuoteMode int
+
+const (
+	// RFC 4180 quoting
+	QuotesStrict QuoteMode = iota
+	// quotes may appear in unquoted fields, quoted fields may have non-doubled quotes
+	QuotesLazy
+	// quotes are ordinary characters, records can not span lines
+	QuotesNone
+)
+
+type HeaderMode int
+
+const (
+	HeaderDetect HeaderMode = iota
+	HeaderPresent
+	HeaderAbsent
+)
+
+var delimiterCandidates = []rune{';', ',', '\t', '|'}
+
+const defaultDelimiter = ';'
+
+// FeedSchema describes feed layout, zero value means detect everything.
+type FeedSchema struct {
+	// detected from the first line if zero
+	Delimiter rune
+	Quotes    QuoteMode
+	Header    HeaderMode
+	// header name, json key or xml path relative to the record element -> product field,
+	// 1-based column number instead of header name for feeds without header,
+	// detected from well known names if empty
+	Columns map[string]string
+	// xml only, path to record elements matched by suffix, defaultXMLRecordPath if empty
+	RecordPath string
+	// overrides content type charset, taken from BOM, content type or xml declaration if empty
+	Charset string
+	Price   PriceFormat
+}
+
+func (s FeedSchema) Validate() error {
+	if s.Delimiter == '"' || s.Delimiter == '\r' || s.Delimiter == '\n' || s.Delimiter == utf8.RuneError {
+		return fmt.Errorf("Validate: invalid delimiter: %q", s.Delimiter)
+	}
+
+	if err := s.Price.Validate(); err != nil {
+		return fmt.Errorf("Validate: %w", err)
+	}
+
+	if s.Charset != "" {
+		if _, err := lookupCharset(s.Charset); err != nil {
+			return fmt.Errorf("Validate: %w", err)
+		}
+	}
+
+	mapped := make(map[string]bool, len(s.Columns))
+	for column, field := range s.Columns {
+		if !isProductField(field) {
+			return fmt.Errorf("Validate: column %s: unknown product field: %s, expected one of: %v", column, field, productFields)
+		}
+		if mapped[field] {
+			return fmt.Errorf("Validate: product field %s mapped more than once", field)
+		}
+		mapped[field] = true
+	}
+	if len(s.Columns) > 0 {
+		for _, field := range requiredProductFields {
+			if !mapped[field] {
+				return fmt.Errorf("Validate: product field %s is not mapped", field)
+			}
+		}
+	}
+
+	return nil
+}
+
+// csvColumns maps product fields to record indexes.
+type csvColumns map[string]int
+
+func defaultCSVColumns() csvColumns {
+	return csvColumns{
+		fieldName:  0,
+		fieldPrice: 1,
+	}
+}
+
+// resolveColumns decides whether the first record is a header and where product fields are.
+func resolveColumns(schema FeedSchema, first []string) (cols csvColumns, header bool, err error) {
+	switch schema.Header {
+	case HeaderPresent:
+		header = true
+	case HeaderAbsent:
+		header = false
+	default:
+		header = looksLikeHeader(schema, first)
+	}
+
+	if !header {
+		cols, err = columnsByNumber(schema)
+		if err != nil {
+			return nil, false, fmt.Errorf("resolveColumns: %w", err)
+		}
+		return cols, false, nil
+	}
+
+	cols, err = columnsByHeader(schema, first)
+	if err != nil {
+		return nil, true, fmt.Errorf("resolveColumns: %w", err)
+	}
+
+	return cols, true, nil
+}
+
+// looksLikeHeader checks for known column names, then for absence of anything resembling a price.
+func looksLikeHeader(schema FeedSchema, first []string) bool {
+	for _, cell := range first {
+		if _, ok := fieldByColumnName(schema, cell); ok {
+			return true
+		}
+	}
+
+	for _, cell := range first {
+		if _, _, err := parsePrice(cell, schema.Price); err == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func columnsByHeader(schema FeedSchema, header []string) (csvColumns, error) {
+	cols := make(csvColumns, len(productFields))
+	for i, cell := range header {
+		field, ok := fieldByColumnName(schema, cell)
+		if !ok {
+			continue
+		}
+		if _, ok := cols[field]; !ok {
+			cols[field] = i
+		}
+	}
+
+	for _, field := range requiredProductFields {
+		if _, ok := cols[field]; ok {
+			continue
+		}
+
+		// header of unknown names, keep with the positional layout
+		if len(schema.Columns) == 0 && len(cols) == 0 {
+			return defaultCSVColumns(), nil
+		}
+
+		return nil, fmt.Errorf("columnsByHeader: no column for product field %s in header: %v", field, header)
+	}
+
+	return cols, nil
+}
+
+func columnsByNumber(schema FeedSchema) (csvColumns, error) {
+	if len(schema.Columns) == 0 {
+		return defaultCSVColumns(), nil
+	}
+
+	cols := make(csvColumns, len(schema.Columns))
+	for column, field := range schema.Columns {
+		n, err := strconv.Atoi(strings.TrimSpace(column))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("columnsByNumber: feed has no header, expected 1-based column number, got: %s", column)
+		}
+		cols[field] = n - 1
+	}
+
+	return cols, nil
+}
+
+func csvRowToProduct(row []string, cols csvColumns, prices PriceFormat) (Product, error) {
+	values := make(map[string]string, len(cols))
+	for field, idx := range cols {
+		if idx < len(row) {
+			values[field] = row[idx]
+		}
+	}
+	for field, idx := range cols {
+		if idx >= len(row) && isRequiredProductField(field) {
+			return Product{Name: values[fieldName]}, fmt.Errorf("csvRowToProduct: row: %v, no column %d for %s, got %d cols", row, idx+1, field, len(row))
+		}
+	}
+
+	p, err := newFeedProduct(values, prices)
+	if err != nil {
+		return p, fmt.Errorf("csvRowToProduct: %w", err)
+	}
+
+	return p, nil
+}
+
+// detectDelimiter picks the most frequent candidate outside quotes in the first line.
+func detectDelimiter(br *bufio.Reader) rune {
+	peeked, _ := br.Peek(br.Size())
+	if i := bytes.IndexByte(peeked, '\n'); i >= 0 {
+		peeked = peeked[:i]
+	}
+
+	counts := make(map[rune]int, len(delimiterCandidates))
+	quoted := false
+	for _, r := range string(peeked) {
+		if r == '"' {
+			quoted = !quoted
+			continue
+		}
+		if !quoted {
+			counts[r]++
+		}
+	}
+
+	best := defaultDelimiter
+	for _, candidate := range delimiterCandidates {
+		if counts[candidate] > counts[best] {
+			best = candidate
+		}
+	}
+
+	return best
+}
+
+type recordReader interface {
+	Read() ([]string, error)
+	// Line is the file line the last record read starts at, blank lines and quoted line breaks count.
+	Line() uint32
+}
+
+func newRecordReader(r *bufio.Reader, delimiter rune, quotes QuoteMode) recordReader {
+	if quotes == QuotesNone {
+		s := bufio.NewScanner(r)
+		s.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+
+		return &plainRecordReader{
+			s:         s,
+			delimiter: string(delimiter),
+		}
+	}
+
+	lr := &lineCountingReader{br: r}
+	cr := csv.NewReader(lr)
+	cr.Comma = delimiter
+	cr.LazyQuotes = quotes == QuotesLazy
+	cr.ReuseRecord = true
+	// row length is checked along with the rest of the row, so it is rejected as a whole
+	cr.FieldsPerRecord = -1
+
+	return &csvRecordReader{cr: cr, lr: lr}
+}
+
+const maxRecordSize = 1024 * 1024
+
+// csvRecordReader tells where records start, csv.Reader does so for parse errors only.
+type csvRecordReader struct {
+	cr   *csv.Reader
+	lr   *lineCountingReader
+	line uint32
+}
+
+func (r *csvRecordReader) Read() ([]string, error) {
+	record, err := r.cr.Read()
+	if err != nil {
+		return record, err
+	}
+
+	// the record ends at the last line read, quoted line breaks are kept in fields as \n
+	breaks := 0
+	for _, field := range record {
+		breaks += strings.Count(field, "\n")
+	}
+	r.line = r.lr.line() - uint32(breaks)
+
+	return record, nil
+}
+
+func (r *csvRecordReader) Line() uint32 {
+	return r.line
+}
+
+// lineCountingReader hands out at most a single line per Read, so csv.Reader never buffers past the record
+// it returns and lines handed out tell the line the record ends at.
+type lineCountingReader struct {
+	br      *bufio.Reader
+	buf     []byte
+	pending []byte
+	// line breaks handed out
+	breaks uint32
+	last   byte
+}
+
+func (r *lineCountingReader) Read(p []byte) (int, error) {
+	if len(r.pending) == 0 {
+		line, err := r.br.ReadSlice('\n')
+		if len(line) == 0 {
+			return 0, err
+		}
+		// the rest of a line too long for the buffer comes with the next call
+		r.buf = append(r.buf[:0], line...)
+		r.pending = r.buf
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	r.breaks += uint32(bytes.Count(p[:n], []byte{'\n'}))
+	r.last = p[n-1]
+
+	return n, nil
+}
+
+// line is the number of the line the last byte handed out belongs to.
+func (r *lineCountingReader) line() uint32 {
+	if r.last == '\n' {
+		return r.breaks
+	}
+	return r.breaks + 1
+}
+
+// plainRecordReader splits lines by delimiter treating quotes as ordinary characters.
+type plainRecordReader struct {
+	s         *bufio.Scanner
+	delimiter string
+	line      uint32
+}
+
+func (r *plainRecordReader) Line() uint32 {
+	return r.line
+}
+
+func (r *plainRecordReader) Read() ([]string, error) {
+	for r.s.Scan() {
+		r.line++
+		line := strings.TrimSuffix(r.s.Text(), "\r")
+		// skipped the same way csv.Reader does
+		if line == "" {
+			continue
+		}
+		return strings.Split(line, r.delimiter), nil
+	}
+	if err := r.s.Err(); err != nil {
+		return nil, fmt.Errorf("Read: %w", err)
+	}
+	return nil, io.EOF
+}
+
+// decodeCSV streams delimited feed rows to fn.
+func decodeCSV(body io.Reader, schema FeedSchema, fn func(row FeedRow) error) error {
+	br := bufio.NewReaderSize(body, 64*1024)
+
+	delimiter := schema.Delimiter
+	if delimiter == 0 {
+		delimiter = detectDelimiter(br)
+	}
+
+	r := newRecordReader(br, delimiter, schema.Quotes)
+
+	var cols csvColumns
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+
+		var parseErr *csv.ParseError
+		if goErrors.As(err, &parseErr) {
+			row := FeedRow{
+				Line: uint32(parseErr.StartLine),
+				Err:  err,
+			}
+			if err := fn(row); err != nil {
+				return fmt.Errorf("decodeCSV: %w", err)
+			}
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("decodeCSV: %w", err)
+		}
+
+		if cols == nil {
+			var header bool
+			cols, header, err = resolveColumns(schema, record)
+			if err != nil {
+				return fmt.Errorf("decodeCSV: %w", err)
+			}
+			if header {
+				continue
+			}
+		}
+
+		row := FeedRow{
+			Line:   r.Line(),
+			Record: append([]string(nil), record...),
+		}
+		row.Product, row.Err = csvRowToProduct(record, cols, schema.Price)
+
+		if err := fn(row); err != nil {
+			return fmt.Errorf("decodeCSV: %w", err)
+		}
+	}
+}
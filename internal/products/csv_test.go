@@ -0,0 +1,182 @@
+package products
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectDelimiter(t *testing.T) {
+	tests := []struct {
+		name  string
+		feed  string
+		delim rune
+	}{
+		{name: "semicolon", feed: "name;price\nfoo;1\n", delim: ';'},
+		{name: "comma", feed: "name,price,currency\nfoo,1,USD\n", delim: ','},
+		{name: "tab", feed: "name\tprice\nfoo\t1\n", delim: '\t'},
+		{name: "pipe", feed: "name|price\nfoo|1\n", delim: '|'},
+		{name: "quoted delimiters ignored", feed: "\"a,b,c\";1\n", delim: ';'},
+		{name: "first line only", feed: "foo;1\nbar,1,2,3,4\n", delim: ';'},
+		{name: "nothing found", feed: "foo\n", delim: defaultDelimiter},
+		{name: "empty", feed: "", delim: defaultDelimiter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectDelimiter(bufio.NewReader(strings.NewReader(tt.feed))); got != tt.delim {
+				t.Errorf("detectDelimiter() = %q, want %q", got, tt.delim)
+			}
+		})
+	}
+}
+
+func TestResolveColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  FeedSchema
+		first   []string
+		cols    csvColumns
+		header  bool
+		wantErr bool
+	}{
+		{
+			name:   "known names",
+			first:  []string{"Price", "Title"},
+			cols:   csvColumns{fieldName: 1, fieldPrice: 0},
+			header: true,
+		},
+		{
+			name:   "first known name wins",
+			first:  []string{"title", "name", "cost"},
+			cols:   csvColumns{fieldName: 0, fieldPrice: 2},
+			header: true,
+		},
+		{
+			name:   "unknown names",
+			first:  []string{"a", "b"},
+			cols:   defaultCSVColumns(),
+			header: true,
+		},
+		{
+			name:   "data",
+			first:  []string{"foo", "1,5"},
+			cols:   defaultCSVColumns(),
+			header: false,
+		},
+		{
+			name:    "header without price",
+			first:   []string{"name", "qty"},
+			header:  true,
+			wantErr: true,
+		},
+		{
+			name:   "mapped names",
+			schema: FeedSchema{Columns: map[string]string{"sku": fieldName, "amount": fieldPrice}},
+			first:  []string{"amount", "sku"},
+			cols:   csvColumns{fieldName: 1, fieldPrice: 0},
+			header: true,
+		},
+		{
+			name:   "mapped numbers",
+			schema: FeedSchema{Header: HeaderAbsent, Columns: map[string]string{"2": fieldName, "3": fieldPrice}},
+			first:  []string{"1", "foo", "2"},
+			cols:   csvColumns{fieldName: 1, fieldPrice: 2},
+			header: false,
+		},
+		{
+			name:    "mapped names without header",
+			schema:  FeedSchema{Header: HeaderAbsent, Columns: map[string]string{"sku": fieldName, "amount": fieldPrice}},
+			first:   []string{"foo", "1"},
+			wantErr: true,
+		},
+		{
+			name:   "header forced",
+			schema: FeedSchema{Header: HeaderPresent},
+			first:  []string{"foo", "1"},
+			cols:   defaultCSVColumns(),
+			header: true,
+		},
+		{
+			name:   "header absent",
+			schema: FeedSchema{Header: HeaderAbsent},
+			first:  []string{"name", "price"},
+			cols:   defaultCSVColumns(),
+			header: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cols, header, err := resolveColumns(tt.schema, tt.first)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if header != tt.header {
+				t.Errorf("resolveColumns() header = %v, want %v", header, tt.header)
+			}
+			if !reflect.DeepEqual(cols, tt.cols) {
+				t.Errorf("resolveColumns() cols = %v, want %v", cols, tt.cols)
+			}
+		})
+	}
+}
+
+func TestDecodeCSVLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		feed  string
+		lines []uint32
+	}{
+		{
+			name:  "header",
+			feed:  "name;price\nfoo;1\nbar;2\n",
+			lines: []uint32{2, 3},
+		},
+		{
+			name:  "no trailing line break",
+			feed:  "foo;1\nbar;2",
+			lines: []uint32{1, 2},
+		},
+		{
+			name:  "crlf",
+			feed:  "name;price\r\nfoo;1\r\nbar;2\r\n",
+			lines: []uint32{2, 3},
+		},
+		{
+			name:  "blank lines",
+			feed:  "name;price\n\nfoo;1\n\n\nbar;2\n",
+			lines: []uint32{3, 6},
+		},
+		{
+			name:  "quoted line breaks",
+			feed:  "name;price\n\"foo\nbaz\";1\nbar;2\n\"qux\n\n\";3\n",
+			lines: []uint32{2, 4, 5},
+		},
+		{
+			name:  "parse error",
+			feed:  "name;price\nfoo;1\n\"bar;2\nbaz;3\n",
+			lines: []uint32{2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lines []uint32
+			err := decodeCSV(strings.NewReader(tt.feed), FeedSchema{}, func(row FeedRow) error {
+				lines = append(lines, row.Line)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("decodeCSV: %v", err)
+			}
+			if !reflect.DeepEqual(lines, tt.lines) {
+				t.Errorf("lines = %v, want %v", lines, tt.lines)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+)
+
+// EgressPolicy limits where the client may go, so Fetch can't be used to reach internal services.
+type EgressPolicy struct {
+	// allowed url schemes, http and https if empty
+	Schemes []string
+	// host names, *.domain wildcards or CIDRs; any public host is allowed if empty.
+	// Allowed hosts may resolve to private addresses.
+	AllowHosts []string
+	// host names, *.domain wildcards or CIDRs, checked before the allowlist
+	DenyHosts []string
+	// lets any host resolve to loopback, private, link-local and other non-public addresses
+	AllowPrivate bool
+	// max redirects followed, zero means none
+	MaxRedirects int
+	// max bytes of response body as downloaded, zero means unlimited
+	MaxResponseSize int64
+}
+
+var defaultEgressSchemes = []string{"http", "https"}
+
+// nonPublicNets are not reachable unless allowed explicitly.
+var nonPublicNets = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"64:ff9b::/96",
+	// Teredo and 6to4 embed IPv4 addresses, private ones included
+	"2001::/32",
+	"2002::/16",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets[i] = n
+	}
+	return nets
+}
+
+// hostList matches hosts by name, wildcard or address.
+type hostList struct {
+	names    map[string]struct{}
+	suffixes []string
+	nets     []*net.IPNet
+}
+
+func newHostList(hosts []string) (hostList, error) {
+	l := hostList{names: make(map[string]struct{})}
+	for _, h := range hosts {
+		h = strings.ToLower(strings.TrimSpace(h))
+		switch {
+		case h == "":
+		case strings.Contains(h, "/"):
+			_, n, err := net.ParseCIDR(h)
+			if err != nil {
+				return l, fmt.Errorf("newHostList: %w", err)
+			}
+			l.nets = append(l.nets, n)
+		case strings.HasPrefix(h, "*."):
+			l.suffixes = append(l.suffixes, h[1:])
+		default:
+			if ip := net.ParseIP(h); ip != nil {
+				l.nets = append(l.nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+				continue
+			}
+			l.names[h] = struct{}{}
+		}
+	}
+	return l, nil
+}
+
+func (l hostList) empty() bool {
+	return len(l.names) == 0 && len(l.suffixes) == 0 && len(l.nets) == 0
+}
+
+func (l hostList) matchName(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if _, ok := l.names[host]; ok {
+		return true
+	}
+	for _, suffix := range l.suffixes {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l hostList) matchIP(ip net.IP) bool {
+	for _, n := range l.nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+type egress struct {
+	policy  EgressPolicy
+	schemes map[string]struct{}
+	allow   hostList
+	deny    hostList
+}
+
+func newEgress(policy EgressPolicy) (*egress, error) {
+	e := &egress{
+		policy:  policy,
+		schemes: make(map[string]struct{}),
+	}
+
+	schemes := policy.Schemes
+	if len(schemes) == 0 {
+		schemes = defaultEgressSchemes
+	}
+	for _, scheme := range schemes {
+		if scheme = strings.ToLower(strings.TrimSpace(scheme)); scheme != "" {
+			e.schemes[scheme] = struct{}{}
+		}
+	}
+
+	var err error
+	if e.allow, err = newHostList(policy.AllowHosts); err != nil {
+		return nil, fmt.Errorf("newEgress: allowed hosts: %w", err)
+	}
+	if e.deny, err = newHostList(policy.DenyHosts); err != nil {
+		return nil, fmt.Errorf("newEgress: denied hosts: %w", err)
+	}
+
+	return e, nil
+}
+
+// checkURL tells whether the url may be requested by its scheme and host name,
+// addresses are checked on dial.
+func (e *egress) checkURL(u *url.URL) error {
+	if err := e.checkScheme(u); err != nil {
+		return fmt.Errorf("checkURL: %w", err)
+	}
+	host := u.Hostname()
+	if host == "" {
+		return errors.NewErrInvalidInput(fmt.Errorf("checkURL: host is required"))
+	}
+	if e.deny.matchName(host) {
+		return errors.NewErrPermissionDenied(fmt.Errorf("checkURL: host %s is denied", host))
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if err := e.checkIP(host, ip); err != nil {
+			return fmt.Errorf("checkURL: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (e *egress) checkScheme(u *url.URL) error {
+	if _, ok := e.schemes[strings.ToLower(u.Scheme)]; !ok {
+		return errors.NewErrInvalidInput(fmt.Errorf("checkScheme: scheme %q is not allowed", u.Scheme))
+	}
+	return nil
+}
+
+// checkIP tells whether the host may be reached at the address it resolved to.
+func (e *egress) checkIP(host string, ip net.IP) error {
+	if e.deny.matchIP(ip) {
+		return errors.NewErrPermissionDenied(fmt.Errorf("checkIP: host %s address %s is denied", host, ip))
+	}
+
+	allowed := e.allow.matchName(host) || e.allow.matchIP(ip)
+	if !allowed && !e.allow.empty() {
+		return errors.NewErrPermissionDenied(fmt.Errorf("checkIP: host %s is not allowed", host))
+	}
+	if !allowed && !e.policy.AllowPrivate && !isPublicIP(ip) {
+		return errors.NewErrPermissionDenied(fmt.Errorf("checkIP: host %s resolves to non-public address %s", host, ip))
+	}
+
+	return nil
+}
+
+func isPublicIP(ip net.IP) bool {
+	for _, n := range nonPublicNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolve checks every address the host resolves to, so the host can't hide a private one behind a public one.
+func (e *egress) resolve(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		if err := e.checkIP(host, ip); err != nil {
+			return nil, fmt.Errorf("resolve: %w", err)
+		}
+		return []net.IP{ip}, nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("resolve: %w", err)
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("resolve: no addresses for host %s", host)
+	}
+	ips := make([]net.IP, len(addrs))
+	for i, addr := range addrs {
+		if err := e.checkIP(host, addr.IP); err != nil {
+			return nil, fmt.Errorf("resolve: %w", err)
+		}
+		ips[i] = addr.IP
+	}
+
+	return ips, nil
+}
+
+// dialContext connects to the very addresses checked, so DNS answering differently on dial can't bypass the policy.
+func (e *egress) dialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("dial: %w", err)
+		}
+		if e.deny.matchName(host) {
+			return nil, errors.NewErrPermissionDenied(fmt.Errorf("dial: host %s is denied", host))
+		}
+
+		ips, err := e.resolve(ctx, host)
+		if err != nil {
+			return nil, fmt.Errorf("dial: %w", err)
+		}
+
+		var conn net.Conn
+		for _, ip := range ips {
+			conn, err = dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+		}
+
+		return nil, fmt.Errorf("dial: %w", err)
+	}
+}
+
+// responseLimitReader fails once the body exceeds EgressPolicy.MaxResponseSize.
+type responseLimitReader struct {
+	r   io.Reader
+	max int64
+	n   int64
+}
+
+func (r *responseLimitReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if r.n > r.max {
+		return n, errors.NewErrPermissionDenied(fmt.Errorf("Read: response is larger than %d bytes", r.max))
+	}
+	return n, err
+}
@@ -0,0 +1,180 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEgressCheckURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy EgressPolicy
+		url    string
+		code   codes.Code
+	}{
+		{name: "public host", url: "https://example.com/feed.csv", code: codes.OK},
+		{name: "public address", url: "http://93.184.216.34/feed.csv", code: codes.OK},
+		{name: "scheme not allowed", url: "ftp://example.com/feed.csv", code: codes.InvalidArgument},
+		{name: "scheme allowed", policy: EgressPolicy{Schemes: []string{"ftp"}}, url: "ftp://example.com/feed.csv", code: codes.OK},
+		{name: "scheme case", url: "HTTPS://example.com/feed.csv", code: codes.OK},
+		{name: "no host", url: "http:///feed.csv", code: codes.InvalidArgument},
+		{name: "denied name", policy: EgressPolicy{DenyHosts: []string{"Example.com"}}, url: "https://example.com./feed.csv", code: codes.PermissionDenied},
+		{name: "denied wildcard", policy: EgressPolicy{DenyHosts: []string{"*.internal"}}, url: "https://feeds.corp.internal/feed.csv", code: codes.PermissionDenied},
+		{name: "wildcard not matching the domain itself", policy: EgressPolicy{DenyHosts: []string{"*.example.com"}}, url: "https://example.com/feed.csv", code: codes.OK},
+		{name: "denied over allowed", policy: EgressPolicy{AllowHosts: []string{"example.com"}, DenyHosts: []string{"example.com"}}, url: "https://example.com/feed.csv", code: codes.PermissionDenied},
+		{name: "denied CIDR", policy: EgressPolicy{DenyHosts: []string{"93.184.0.0/16"}}, url: "http://93.184.216.34/feed.csv", code: codes.PermissionDenied},
+		{name: "loopback", url: "http://127.0.0.1:8080/feed.csv", code: codes.PermissionDenied},
+		{name: "loopback v6", url: "http://[::1]:8080/feed.csv", code: codes.PermissionDenied},
+		{name: "private", url: "http://10.1.2.3/feed.csv", code: codes.PermissionDenied},
+		{name: "link local", url: "http://169.254.169.254/latest/meta-data", code: codes.PermissionDenied},
+		{name: "unique local v6", url: "http://[fd00::1]/feed.csv", code: codes.PermissionDenied},
+		{name: "6to4", url: "http://[2002:a01:203::1]/feed.csv", code: codes.PermissionDenied},
+		{name: "teredo", url: "http://[2001:0:4136:e378:8000:63bf:f5fe:fdfc]/feed.csv", code: codes.PermissionDenied},
+		{name: "private allowed", policy: EgressPolicy{AllowPrivate: true}, url: "http://10.1.2.3/feed.csv", code: codes.OK},
+		{name: "private allowed by CIDR", policy: EgressPolicy{AllowHosts: []string{"10.1.0.0/16"}}, url: "http://10.1.2.3/feed.csv", code: codes.OK},
+		{name: "private allowed by address", policy: EgressPolicy{AllowHosts: []string{"10.1.2.3"}}, url: "http://10.1.2.3/feed.csv", code: codes.OK},
+		{name: "not in allowlist", policy: EgressPolicy{AllowHosts: []string{"10.1.0.0/16"}}, url: "http://93.184.216.34/feed.csv", code: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := newEgress(tt.policy)
+			if err != nil {
+				t.Fatalf("newEgress: %v", err)
+			}
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatalf("url.Parse: %v", err)
+			}
+			if code := egressCode(e.checkURL(u)); code != tt.code {
+				t.Errorf("checkURL(%s) = %s, want %s", tt.url, code, tt.code)
+			}
+		})
+	}
+}
+
+func TestEgressCheckIP(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy EgressPolicy
+		host   string
+		ip     string
+		code   codes.Code
+	}{
+		{name: "public", host: "example.com", ip: "93.184.216.34", code: codes.OK},
+		{name: "public v6", host: "example.com", ip: "2606:2800:220:1:248:1893:25c8:1946", code: codes.OK},
+		{name: "private", host: "example.com", ip: "192.168.1.10", code: codes.PermissionDenied},
+		{name: "shared address space", host: "example.com", ip: "100.64.0.1", code: codes.PermissionDenied},
+		{name: "unspecified", host: "example.com", ip: "0.0.0.0", code: codes.PermissionDenied},
+		{name: "multicast", host: "example.com", ip: "224.0.0.1", code: codes.PermissionDenied},
+		{name: "v4 mapped loopback", host: "example.com", ip: "::ffff:127.0.0.1", code: codes.PermissionDenied},
+		{name: "6to4 of private", host: "example.com", ip: "2002:c0a8:10a::1", code: codes.PermissionDenied},
+		{name: "teredo", host: "example.com", ip: "2001:0:4136:e378:8000:63bf:3f57:fef5", code: codes.PermissionDenied},
+		{name: "6to4 allowed explicitly", policy: EgressPolicy{AllowHosts: []string{"2002::/16"}}, host: "example.com", ip: "2002:c0a8:10a::1", code: codes.OK},
+		{name: "allowed name resolving to private", policy: EgressPolicy{AllowHosts: []string{"feeds.local"}}, host: "feeds.local", ip: "192.168.1.10", code: codes.OK},
+		{name: "allowed wildcard resolving to private", policy: EgressPolicy{AllowHosts: []string{"*.local"}}, host: "feeds.local", ip: "192.168.1.10", code: codes.OK},
+		{name: "name not in allowlist", policy: EgressPolicy{AllowHosts: []string{"feeds.local"}}, host: "example.com", ip: "93.184.216.34", code: codes.PermissionDenied},
+		{name: "denied address of allowed name", policy: EgressPolicy{AllowHosts: []string{"feeds.local"}, DenyHosts: []string{"192.168.0.0/16"}}, host: "feeds.local", ip: "192.168.1.10", code: codes.PermissionDenied},
+		{name: "denied address of public name", policy: EgressPolicy{DenyHosts: []string{"93.184.216.34"}}, host: "example.com", ip: "93.184.216.34", code: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := newEgress(tt.policy)
+			if err != nil {
+				t.Fatalf("newEgress: %v", err)
+			}
+			if code := egressCode(e.checkIP(tt.host, net.ParseIP(tt.ip))); code != tt.code {
+				t.Errorf("checkIP(%s, %s) = %s, want %s", tt.host, tt.ip, code, tt.code)
+			}
+		})
+	}
+}
+
+func TestEgressResolve(t *testing.T) {
+	e, err := newEgress(EgressPolicy{})
+	if err != nil {
+		t.Fatalf("newEgress: %v", err)
+	}
+	for _, host := range []string{"localhost", "127.0.0.1"} {
+		if _, err := e.resolve(context.Background(), host); egressCode(err) != codes.PermissionDenied {
+			t.Errorf("resolve(%s) error = %v, want permission denied", host, err)
+		}
+	}
+
+	e, err = newEgress(EgressPolicy{AllowPrivate: true})
+	if err != nil {
+		t.Fatalf("newEgress: %v", err)
+	}
+	ips, err := e.resolve(context.Background(), "127.0.0.1")
+	if err != nil || len(ips) != 1 || !ips[0].Equal(net.IPv4(127, 0, 0, 1)) {
+		t.Errorf("resolve(127.0.0.1) = %v, %v", ips, err)
+	}
+}
+
+func TestNewEgressInvalidCIDR(t *testing.T) {
+	if _, err := newEgress(EgressPolicy{AllowHosts: []string{"10.0.0.0/33"}}); err == nil {
+		t.Error("newEgress() error = nil for invalid allowed CIDR")
+	}
+	if _, err := newEgress(EgressPolicy{DenyHosts: []string{"10.0.0.0/x"}}); err == nil {
+		t.Error("newEgress() error = nil for invalid denied CIDR")
+	}
+}
+
+func TestEgressResponseLimit(t *testing.T) {
+	feed := "name;price\n" + strings.Repeat("product;1\n", 1000)
+
+	tests := []struct {
+		name    string
+		max     int64
+		chunked bool
+		code    codes.Code
+	}{
+		{name: "unlimited", code: codes.OK},
+		{name: "within limit", max: int64(len(feed)), code: codes.OK},
+		{name: "content length over limit", max: 1000, code: codes.PermissionDenied},
+		{name: "chunked body over limit", max: 1000, chunked: true, code: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.chunked {
+					for _, line := range strings.SplitAfter(feed, "\n") {
+						fmt.Fprint(w, line)
+						w.(http.Flusher).Flush()
+					}
+					return
+				}
+				w.Header().Set("Content-Length", fmt.Sprint(len(feed)))
+				fmt.Fprint(w, feed)
+			}))
+			defer srv.Close()
+
+			cli, err := NewClient(ClientConfig{Egress: EgressPolicy{AllowPrivate: true, MaxResponseSize: tt.max}})
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			_, err = cli.List(context.Background(), srv.URL+"/feed.csv", func(row FeedRow) error { return nil })
+			if code := egressCode(err); code != tt.code {
+				t.Errorf("List() error = %v, want %s", err, tt.code)
+			}
+		})
+	}
+}
+
+func egressCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	return status.Code(statusError("egress", err))
+}
@@ -0,0 +1,248 @@
+package products
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"path"
+	"strings"
+	"unicode/utf8"
+)
+
+type FeedFormat string
+
+const (
+	FormatDetect FeedFormat = ""
+	FormatCSV    FeedFormat = "csv"
+	FormatJSON   FeedFormat = "json"
+	FormatNDJSON FeedFormat = "ndjson"
+	FormatXML    FeedFormat = "xml"
+)
+
+func (f FeedFormat) Validate() error {
+	if f == FormatDetect {
+		return nil
+	}
+	if _, ok := feedDecoders[f]; !ok {
+		return fmt.Errorf("Validate: unknown feed format: %s", f)
+	}
+	return nil
+}
+
+// feedDecoder streams rows of the feed to fn, rows failed to parse or validate carry FeedRow.Err,
+// error is returned only if the feed can not be read any further.
+type feedDecoder func(body io.Reader, schema FeedSchema, fn func(row FeedRow) error) error
+
+var feedDecoders = map[FeedFormat]feedDecoder{
+	FormatCSV:    decodeCSV,
+	FormatJSON:   decodeJSON,
+	FormatNDJSON: decodeNDJSON,
+	FormatXML:    decodeXML,
+}
+
+var contentTypeFormats = map[string]FeedFormat{
+	"text/csv":                  FormatCSV,
+	"application/csv":           FormatCSV,
+	"text/tab-separated-values": FormatCSV,
+	"application/json":          FormatJSON,
+	"text/json":                 FormatJSON,
+	"application/x-ndjson":      FormatNDJSON,
+	"application/ndjson":        FormatNDJSON,
+	"application/jsonl":         FormatNDJSON,
+	"application/x-jsonlines":   FormatNDJSON,
+	"application/xml":           FormatXML,
+	"text/xml":                  FormatXML,
+}
+
+var extensionFormats = map[string]FeedFormat{
+	".csv":    FormatCSV,
+	".tsv":    FormatCSV,
+	".txt":    FormatCSV,
+	".json":   FormatJSON,
+	".ndjson": FormatNDJSON,
+	".jsonl":  FormatNDJSON,
+	".xml":    FormatXML,
+	".yml":    FormatXML,
+}
+
+// feedAccept lists every supported content type, csv preferred.
+const feedAccept = "text/csv, application/json;q=0.9, application/x-ndjson;q=0.9, application/xml;q=0.9, */*;q=0.5"
+
+// detectFormat trusts explicit format, then content type, then url extension, then the feed content itself.
+func detectFormat(explicit FeedFormat, contentType, rawURL string, schema FeedSchema, br *bufio.Reader) FeedFormat {
+	if explicit != FormatDetect {
+		return explicit
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		if f, ok := contentTypeFormats[mediaType]; ok {
+			return f
+		}
+	}
+
+	if u, err := url.Parse(rawURL); err == nil {
+		if f, ok := extensionFormats[strings.ToLower(path.Ext(u.Path))]; ok {
+			return f
+		}
+	}
+
+	return sniffFormat(br, schema)
+}
+
+func sniffFormat(br *bufio.Reader, schema FeedSchema) FeedFormat {
+	peeked, _ := br.Peek(br.Size())
+	peeked = bytes.TrimLeft(peeked, " \t\r\n")
+
+	if len(peeked) == 0 {
+		return FormatCSV
+	}
+
+	switch peeked[0] {
+	case '<':
+		return FormatXML
+	case '[':
+		return FormatJSON
+	case '{':
+		if isWrapperObject(peeked, schema) {
+			return FormatJSON
+		}
+		return FormatNDJSON
+	default:
+		return FormatCSV
+	}
+}
+
+// isWrapperObject tells products array wrapper from the first product of ndjson feed.
+func isWrapperObject(peeked []byte, schema FeedSchema) bool {
+	if i := bytes.IndexByte(peeked, '\n'); i >= 0 {
+		peeked = peeked[:i]
+	}
+
+	// object spanning lines or too long to peek is not ndjson anyway
+	var first map[string]json.RawMessage
+	if err := json.Unmarshal(peeked, &first); err != nil {
+		return true
+	}
+
+	for key := range first {
+		if _, ok := fieldByColumnName(schema, key); ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+const (
+	fieldName       = "name"
+	fieldPrice      = "price"
+	fieldExternalID = "externalId"
+	fieldCurrency   = "currency"
+)
+
+var productFields = []string{
+	fieldName,
+	fieldPrice,
+	fieldExternalID,
+	fieldCurrency,
+}
+
+var requiredProductFields = []string{
+	fieldName,
+	fieldPrice,
+}
+
+// well known column names used if no columns mapping provided
+var fieldAliases = map[string][]string{
+	fieldName:       {"name", "product", "product_name", "productname", "title", "наименование", "название", "товар"},
+	fieldPrice:      {"price", "cost", "цена", "стоимость"},
+	fieldExternalID: {"id", "@id", "sku", "offer_id", "offerid", "article", "артикул"},
+	fieldCurrency:   {"currency", "currencyid", "валюта"},
+}
+
+func isProductField(field string) bool {
+	for _, f := range productFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func isRequiredProductField(field string) bool {
+	for _, f := range requiredProductFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeColumnName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func fieldByColumnName(schema FeedSchema, name string) (string, bool) {
+	name = normalizeColumnName(name)
+
+	for column, field := range schema.Columns {
+		if normalizeColumnName(column) == name {
+			return field, true
+		}
+	}
+	if len(schema.Columns) > 0 {
+		return "", false
+	}
+
+	for field, aliases := range fieldAliases {
+		for _, alias := range aliases {
+			if alias == name {
+				return field, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// newFeedProduct validates product field values the same way for every feed format.
+// Product failed validation keeps the name if there is one, so full sync does not take it for missing.
+func newFeedProduct(values map[string]string, prices PriceFormat) (Product, error) {
+	for _, field := range requiredProductFields {
+		if _, ok := values[field]; !ok {
+			return Product{Name: values[fieldName]}, fmt.Errorf("newFeedProduct: no value for product field %s", field)
+		}
+	}
+
+	name := values[fieldName]
+	if strings.TrimSpace(name) == "" {
+		return Product{}, fmt.Errorf("newFeedProduct: empty product name")
+	}
+	if !utf8.ValidString(name) {
+		return Product{}, fmt.Errorf("newFeedProduct: product name is not valid utf-8, feed charset may be set in schema")
+	}
+
+	price, priceCurrency, err := parsePrice(values[fieldPrice], prices)
+	if err != nil {
+		return Product{Name: name}, fmt.Errorf("newFeedProduct: %w", err)
+	}
+
+	currency := strings.ToUpper(strings.TrimSpace(values[fieldCurrency]))
+	if currency == "" {
+		currency = priceCurrency
+	}
+	if priceCurrency != "" && priceCurrency != currency {
+		return Product{Name: name}, fmt.Errorf("newFeedProduct: price currency %s does not match currency %s", priceCurrency, currency)
+	}
+
+	return Product{
+		Name:       name,
+		Price:      price,
+		ExternalID: strings.TrimSpace(values[fieldExternalID]),
+		Currency:   currency,
+	}, nil
+}
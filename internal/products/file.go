@@ -0,0 +1,130 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+)
+
+// fileRoots are the directories file:// feeds may be read from, none are allowed if empty.
+type fileRoots []string
+
+func newFileRoots(dirs []string) (fileRoots, error) {
+	var roots fileRoots
+	for _, dir := range dirs {
+		if dir = strings.TrimSpace(dir); dir == "" {
+			continue
+		}
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, fmt.Errorf("newFileRoots: %w", err)
+		}
+		roots = append(roots, abs)
+	}
+	return roots, nil
+}
+
+// checkURL tells whether the file url lies within the roots, symlinks are not followed.
+func (r fileRoots) checkURL(u *url.URL) (string, error) {
+	if len(r) == 0 {
+		return "", errors.NewErrInvalidInput(fmt.Errorf("checkURL: file feeds are not allowed"))
+	}
+	if u.Host != "" && u.Host != "localhost" {
+		return "", errors.NewErrInvalidInput(fmt.Errorf("checkURL: remote file host %s is not supported", u.Host))
+	}
+	if u.Path == "" || !filepath.IsAbs(u.Path) {
+		return "", errors.NewErrInvalidInput(fmt.Errorf("checkURL: absolute file path is required"))
+	}
+
+	path := filepath.Clean(u.Path)
+	for _, root := range r {
+		if within(root, path) {
+			return path, nil
+		}
+	}
+
+	return "", errors.NewErrPermissionDenied(fmt.Errorf("checkURL: file %s is outside of allowed dirs", path))
+}
+
+// open opens the file and then checks where it is, following symlinks of both the file and the roots,
+// so a link can't lead outside of them, not even one swapped in while the file is being opened.
+func (r fileRoots) open(u *url.URL) (*os.File, error) {
+	path, err := r.checkURL(u)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NewErrNotFound(fmt.Errorf("open: file %s does not exist", path))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	if err := r.checkOpened(path, f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	return f, nil
+}
+
+// checkOpened tells whether the path resolves within the roots to the very file opened.
+func (r fileRoots) checkOpened(path string, f *os.File) error {
+	real, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return fmt.Errorf("checkOpened: %w", err)
+	}
+
+	opened, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("checkOpened: %w", err)
+	}
+	checked, err := os.Stat(real)
+	if err != nil {
+		return fmt.Errorf("checkOpened: %w", err)
+	}
+	if !os.SameFile(opened, checked) {
+		return errors.NewErrPermissionDenied(fmt.Errorf("checkOpened: file %s changed while being opened", path))
+	}
+
+	for _, root := range r {
+		realRoot, err := filepath.EvalSymlinks(root)
+		if err != nil {
+			continue
+		}
+		if within(realRoot, real) {
+			return nil
+		}
+	}
+
+	return errors.NewErrPermissionDenied(fmt.Errorf("checkOpened: file %s links outside of allowed dirs", path))
+}
+
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// contextReader stops reading once the context is done, files don't watch the context on their own.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,102 @@
+package products
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestFileRootsOpen(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "feeds")
+	outside := filepath.Join(dir, "secret.csv")
+	mustWriteFile(t, outside, "name;price\n")
+	mustWriteFile(t, filepath.Join(root, "daily", "feed.csv"), "name;price\n")
+	if err := os.Symlink(outside, filepath.Join(root, "leak.csv")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(root, "daily", "feed.csv"), filepath.Join(root, "latest.csv")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(dir, filepath.Join(root, "up")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		roots []string
+		url   string
+		code  codes.Code
+	}{
+		{name: "file within root", roots: []string{root}, url: "file://" + root + "/daily/feed.csv", code: codes.OK},
+		{name: "localhost", roots: []string{root}, url: "file://localhost" + root + "/daily/feed.csv", code: codes.OK},
+		{name: "link within root", roots: []string{root}, url: "file://" + root + "/latest.csv", code: codes.OK},
+		{name: "no roots", url: "file://" + root + "/daily/feed.csv", code: codes.InvalidArgument},
+		{name: "remote host", roots: []string{root}, url: "file://feeds.example.com" + root + "/daily/feed.csv", code: codes.InvalidArgument},
+		{name: "outside of roots", roots: []string{root}, url: "file://" + outside, code: codes.PermissionDenied},
+		{name: "dot dot", roots: []string{root}, url: "file://" + root + "/../secret.csv", code: codes.PermissionDenied},
+		{name: "file link outside", roots: []string{root}, url: "file://" + root + "/leak.csv", code: codes.PermissionDenied},
+		{name: "dir link outside", roots: []string{root}, url: "file://" + root + "/up/secret.csv", code: codes.PermissionDenied},
+		{name: "missing", roots: []string{root}, url: "file://" + root + "/daily/missing.csv", code: codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roots, err := newFileRoots(tt.roots)
+			if err != nil {
+				t.Fatalf("newFileRoots: %v", err)
+			}
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatalf("url.Parse: %v", err)
+			}
+			f, err := roots.open(u)
+			if err == nil {
+				f.Close()
+			}
+			if code := egressCode(err); code != tt.code {
+				t.Errorf("open(%s) error = %v, want %s", tt.url, err, tt.code)
+			}
+		})
+	}
+}
+
+func TestFileRootsCheckOpened(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "feed.csv")
+	mustWriteFile(t, path, "name;price\n")
+	mustWriteFile(t, filepath.Join(root, "other.csv"), "name;price\n")
+
+	roots, err := newFileRoots([]string{root})
+	if err != nil {
+		t.Fatalf("newFileRoots: %v", err)
+	}
+
+	f, err := os.Open(filepath.Join(root, "other.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	// the path leads to another file than the one opened, as if it was swapped in between
+	if code := egressCode(roots.checkOpened(path, f)); code != codes.PermissionDenied {
+		t.Errorf("checkOpened() = %s, want %s", code, codes.PermissionDenied)
+	}
+	if err := roots.checkOpened(f.Name(), f); err != nil {
+		t.Errorf("checkOpened() error = %v", err)
+	}
+}
+
+func mustWriteFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
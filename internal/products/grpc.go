@@ -4,6 +4,7 @@ import (
 	"context"
 	goErrors "errors"
 	"fmt"
+	"time"
 
 	"github.com/marknovikov/products-demo/internal/errors"
 	"github.com/marknovikov/products-demo/pkg/productspb"
@@ -29,56 +30,358 @@ func NewGrpcServer(s Service) productspb.ProductsServer {
 func (srv *grpcServer) Fetch(ctx context.Context, req *productspb.FetchRequest) (*productspb.FetchResponse, error) {
 	resp := &productspb.FetchResponse{}
 
-	err := srv.s.Fetch(ctx, req.Url)
+	opts, err := fetchOptions(req)
+	if err != nil {
+		return resp, status.Errorf(codes.InvalidArgument, "Fetch: %v", err)
+	}
+	opts = append(opts, Options().WithWait(req.Wait))
 
-	var invalidInput errors.ErrInvalidInput
-	if goErrors.As(err, &invalidInput) {
-		return resp, status.Errorf(codes.InvalidArgument, "Fetch: %w", err)
+	if req.DryRun {
+		diff, err := srv.s.DiffFetch(ctx, req.Url, opts...)
+		if err != nil {
+			return resp, statusError("Fetch", err)
+		}
+
+		resp.Report = toIngestionReportPb(diff.Report)
+		resp.Diff = toFetchDiffPb(diff)
+
+		return resp, nil
 	}
+
+	job, err := srv.s.Fetch(ctx, req.Url, opts...)
+	resp.JobId = job.ID
 	if err != nil {
-		return resp, status.Errorf(codes.Internal, "Fetch: %w", err)
+		return resp, statusError("Fetch", err)
+	}
+
+	if req.Wait {
+		resp.Report = toIngestionReportPb(job.Report)
 	}
 
 	return resp, nil
 }
 
+func (srv *grpcServer) FetchBatch(ctx context.Context, req *productspb.FetchBatchRequest) (*productspb.FetchBatchResponse, error) {
+	resp := &productspb.FetchBatchResponse{}
+
+	items := make([]FetchBatchItem, len(req.Feeds))
+	for i, feed := range req.Feeds {
+		if feed.DryRun {
+			return resp, status.Errorf(codes.InvalidArgument, "FetchBatch: feed %d: dry run is not supported", i)
+		}
+		opts, err := fetchOptions(feed)
+		if err != nil {
+			return resp, status.Errorf(codes.InvalidArgument, "FetchBatch: feed %d: %v", i, err)
+		}
+		items[i] = FetchBatchItem{URL: feed.Url, Options: opts}
+	}
+
+	results, err := srv.s.FetchBatch(ctx, items)
+	if err != nil {
+		return resp, statusError("FetchBatch", err)
+	}
+
+	for _, res := range results {
+		resPb := &productspb.FetchBatchResult{
+			Url:    res.URL,
+			Source: res.Source,
+		}
+		if res.Job.ID != "" {
+			resPb.Job = toFetchJobPb(res.Job)
+		}
+		if res.Err != nil {
+			resPb.Error = res.Err.Error()
+		}
+		resp.Results = append(resp.Results, resPb)
+	}
+
+	return resp, nil
+}
+
+func (srv *grpcServer) GetFetchJob(ctx context.Context, req *productspb.GetFetchJobRequest) (*productspb.FetchJob, error) {
+	job, err := srv.s.GetFetchJob(ctx, req.Id)
+	if err != nil {
+		return &productspb.FetchJob{}, statusError("GetFetchJob", err)
+	}
+
+	return toFetchJobPb(job), nil
+}
+
+func (srv *grpcServer) ListFetchJobs(ctx context.Context, req *productspb.ListFetchJobsRequest) (*productspb.ListFetchJobsResponse, error) {
+	resp := &productspb.ListFetchJobsResponse{}
+
+	filter := FetchJobsFilter{
+		Limit:  req.Limit,
+		LastID: req.LastId,
+	}
+	for _, state := range req.States {
+		filter.States = append(filter.States, fetchJobStatesFromPb[state])
+	}
+
+	jj, err := srv.s.ListFetchJobs(ctx, filter)
+	if err != nil {
+		return resp, statusError("ListFetchJobs", err)
+	}
+
+	resp.Jobs = make([]*productspb.FetchJob, len(jj))
+	for i, job := range jj {
+		resp.Jobs[i] = toFetchJobPb(job)
+	}
+
+	return resp, nil
+}
+
+func (srv *grpcServer) CancelFetchJob(ctx context.Context, req *productspb.CancelFetchJobRequest) (*productspb.FetchJob, error) {
+	job, err := srv.s.CancelFetchJob(ctx, req.Id)
+	if err != nil {
+		return &productspb.FetchJob{}, statusError("CancelFetchJob", err)
+	}
+
+	return toFetchJobPb(job), nil
+}
+
+func (srv *grpcServer) GetFetchJobErrors(req *productspb.GetFetchJobErrorsRequest, stream productspb.Products_GetFetchJobErrorsServer) error {
+	err := srv.s.GetFetchJobErrors(stream.Context(), req.Id, func(e RowError) error {
+		return stream.Send(toRowErrorPb(e))
+	})
+	if err != nil {
+		return statusError("GetFetchJobErrors", err)
+	}
+
+	return nil
+}
+
 func (srv *grpcServer) List(ctx context.Context, req *productspb.ListRequest) (*productspb.ListResponse, error) {
 	resp := &productspb.ListResponse{}
 
-	var opts []option
+	opts := []option{Options().WithIncludeArchived(req.IncludeArchived)}
 	if err := applyPaging(&opts, req); err != nil {
-		return resp, status.Errorf(codes.InvalidArgument, "List: %w", err)
+		return resp, status.Errorf(codes.InvalidArgument, "List: %v", err)
 	}
 	if err := applySorting(&opts, req); err != nil {
-		return resp, status.Errorf(codes.InvalidArgument, "List: %w", err)
+		return resp, status.Errorf(codes.InvalidArgument, "List: %v", err)
+	}
+	if err := applyAsOf(&opts, req); err != nil {
+		return resp, status.Errorf(codes.InvalidArgument, "List: %v", err)
 	}
 
 	pp, err := srv.s.List(ctx, opts...)
-
-	var invalidInput errors.ErrInvalidInput
-	if goErrors.As(err, &invalidInput) {
-		return resp, status.Errorf(codes.InvalidArgument, "List: %w", err)
-	}
 	if err != nil {
-		return resp, status.Errorf(codes.Internal, "List: %w", err)
+		return resp, statusError("List", err)
 	}
 
 	resp.Products = make([]*productspb.Product, 0, len(pp))
 
 	resp.Products = make([]*productspb.Product, len(pp))
 	for i, p := range pp {
-		resp.Products[i] = &productspb.Product{
-			Id:               p.ID,
-			Name:             p.Name,
-			Price:            p.Price.StringFixed(2),
-			PriceUpdateCount: p.PriceUpdateCount,
-			LastModified:     timestamppb.New(p.LastModified),
-		}
+		resp.Products[i] = toProductPb(p)
+	}
+
+	return resp, nil
+}
+
+func toProductPb(p Product) *productspb.Product {
+	return &productspb.Product{
+		Id:               p.ID,
+		Name:             p.Name,
+		Price:            p.Price.StringFixed(2),
+		PriceUpdateCount: p.PriceUpdateCount,
+		LastModified:     timestamppb.New(p.LastModified),
+		ExternalId:       p.ExternalID,
+		Currency:         p.Currency,
+		Source:           p.Source,
+		Archived:         p.Archived,
+		ArchivedAt:       toTimestampPb(p.ArchivedAt),
+	}
+}
+
+func (srv *grpcServer) GetPriceHistory(ctx context.Context, req *productspb.GetPriceHistoryRequest) (*productspb.GetPriceHistoryResponse, error) {
+	resp := &productspb.GetPriceHistoryResponse{}
+
+	filter := PriceHistoryFilter{
+		ProductID: req.ProductId,
+		Name:      req.Name,
+		Limit:     req.Limit,
+		LastID:    req.LastId,
+	}
+	if req.From != nil {
+		filter.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		filter.To = req.To.AsTime()
+	}
+
+	cc, err := srv.s.GetPriceHistory(ctx, filter)
+	if err != nil {
+		return resp, statusError("GetPriceHistory", err)
+	}
+
+	resp.Changes = make([]*productspb.PriceChange, len(cc))
+	for i, c := range cc {
+		resp.Changes[i] = toPriceChangePb(c)
 	}
 
 	return resp, nil
 }
 
+func (srv *grpcServer) RestoreProduct(ctx context.Context, req *productspb.RestoreProductRequest) (*productspb.Product, error) {
+	p, err := srv.s.RestoreProduct(ctx, req.Id)
+	if err != nil {
+		return &productspb.Product{}, statusError("RestoreProduct", err)
+	}
+
+	return toProductPb(p), nil
+}
+
+func statusError(method string, err error) error {
+	var invalidInput errors.ErrInvalidInput
+	if goErrors.As(err, &invalidInput) {
+		return status.Errorf(codes.InvalidArgument, "%s: %v", method, err)
+	}
+
+	var notFound errors.ErrNotFound
+	if goErrors.As(err, &notFound) {
+		return status.Errorf(codes.NotFound, "%s: %v", method, err)
+	}
+
+	var alreadyExists errors.ErrAlreadyExists
+	if goErrors.As(err, &alreadyExists) {
+		return status.Errorf(codes.AlreadyExists, "%s: %v", method, err)
+	}
+
+	var permissionDenied errors.ErrPermissionDenied
+	if goErrors.As(err, &permissionDenied) {
+		return status.Errorf(codes.PermissionDenied, "%s: %v", method, err)
+	}
+
+	return status.Errorf(codes.Internal, "%s: %v", method, err)
+}
+
+var breakerStatesToPb = map[BreakerState]productspb.IngestionReport_Breaker{
+	BreakerClosed:   productspb.IngestionReport_CLOSED,
+	BreakerOpen:     productspb.IngestionReport_OPEN,
+	BreakerHalfOpen: productspb.IngestionReport_HALF_OPEN,
+}
+
+var fetchJobStatesToPb = map[FetchJobState]productspb.FetchJob_State{
+	FetchJobStatePending:   productspb.FetchJob_PENDING,
+	FetchJobStateRunning:   productspb.FetchJob_RUNNING,
+	FetchJobStateSucceeded: productspb.FetchJob_SUCCEEDED,
+	FetchJobStateFailed:    productspb.FetchJob_FAILED,
+	FetchJobStateCanceled:  productspb.FetchJob_CANCELED,
+}
+
+var fetchJobStatesFromPb = map[productspb.FetchJob_State]FetchJobState{
+	productspb.FetchJob_PENDING:   FetchJobStatePending,
+	productspb.FetchJob_RUNNING:   FetchJobStateRunning,
+	productspb.FetchJob_SUCCEEDED: FetchJobStateSucceeded,
+	productspb.FetchJob_FAILED:    FetchJobStateFailed,
+	productspb.FetchJob_CANCELED:  FetchJobStateCanceled,
+}
+
+func toTimestampPb(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func toIngestionReportPb(r IngestionReport) *productspb.IngestionReport {
+	pb := &productspb.IngestionReport{
+		Parsed:    r.Parsed,
+		Written:   r.Written,
+		Inserted:  r.Inserted,
+		Repriced:  r.Repriced,
+		Unchanged: r.Unchanged,
+		Rejected:  r.Rejected,
+		Errors:    make([]*productspb.RowError, len(r.Errors)),
+		Archived:  r.Archived,
+		Restored:  r.Restored,
+		Skipped:   r.Skipped,
+		Retries:   r.Retries,
+		Breaker:   breakerStatesToPb[r.Breaker],
+	}
+	for i, e := range r.Errors {
+		pb.Errors[i] = toRowErrorPb(e)
+	}
+	return pb
+}
+
+func toPriceDeltaPb(d PriceDelta) *productspb.PriceDelta {
+	return &productspb.PriceDelta{
+		Product:  toProductPb(d.Product),
+		OldPrice: d.OldPrice.StringFixed(2),
+		Delta:    d.Delta.StringFixed(2),
+	}
+}
+
+func toFetchDiffPb(d FetchDiff) *productspb.FetchDiff {
+	pb := &productspb.FetchDiff{
+		Increased: d.Increased,
+		Decreased: d.Decreased,
+	}
+	for _, p := range d.New {
+		pb.NewProducts = append(pb.NewProducts, toProductPb(p))
+	}
+	for _, delta := range d.Increases {
+		pb.Increases = append(pb.Increases, toPriceDeltaPb(delta))
+	}
+	for _, delta := range d.Decreases {
+		pb.Decreases = append(pb.Decreases, toPriceDeltaPb(delta))
+	}
+	return pb
+}
+
+var priceEventsToPb = map[PriceEvent]productspb.PriceChange_Event{
+	PriceEventPrice:    productspb.PriceChange_PRICE,
+	PriceEventArchived: productspb.PriceChange_ARCHIVED,
+	PriceEventRestored: productspb.PriceChange_RESTORED,
+}
+
+func toPriceChangePb(c PriceChange) *productspb.PriceChange {
+	pb := &productspb.PriceChange{
+		Id:        c.ID,
+		Event:     priceEventsToPb[c.Event],
+		ProductId: c.ProductID,
+		Name:      c.Name,
+		NewPrice:  c.NewPrice.StringFixed(2),
+		Currency:  c.Currency,
+		ChangedAt: toTimestampPb(c.ChangedAt),
+		Source:    c.Source,
+		JobId:     c.JobID,
+	}
+	if c.OldPrice != nil {
+		pb.OldPrice = c.OldPrice.StringFixed(2)
+	}
+	return pb
+}
+
+func toRowErrorPb(e RowError) *productspb.RowError {
+	return &productspb.RowError{
+		Line:   e.Line,
+		Reason: e.Reason,
+		Record: e.Record,
+		Entry:  e.Entry,
+	}
+}
+
+func toFetchJobPb(job FetchJob) *productspb.FetchJob {
+	return &productspb.FetchJob{
+		Id:              job.ID,
+		Url:             job.URL,
+		Source:          job.Source,
+		State:           fetchJobStatesToPb[job.State],
+		Instance:        job.Instance,
+		CancelRequested: job.CancelRequested,
+		CreatedAt:       toTimestampPb(job.CreatedAt),
+		StartedAt:       toTimestampPb(job.StartedAt),
+		FinishedAt:      toTimestampPb(job.FinishedAt),
+		HeartbeatAt:     toTimestampPb(job.HeartbeatAt),
+		Report:          toIngestionReportPb(job.Report),
+		Error:           job.Error,
+	}
+}
+
 func toProduct(pb *productspb.Product) (*Product, error) {
 	if pb == nil {
 		return nil, nil
@@ -105,6 +408,27 @@ func toProduct(pb *productspb.Product) (*Product, error) {
 	return p, nil
 }
 
+func applyAsOf(opts *[]option, req *productspb.ListRequest) error {
+	if opts == nil {
+		return fmt.Errorf("opts is nil")
+	}
+
+	if req == nil || req.AsOf == nil {
+		return nil
+	}
+
+	if err := req.AsOf.CheckValid(); err != nil {
+		return fmt.Errorf("applyAsOf: %w", err)
+	}
+
+	optsVal := *opts
+	optsVal = append(optsVal, Options().WithAsOf(req.AsOf.AsTime()))
+
+	*opts = optsVal
+
+	return nil
+}
+
 func applyPaging(opts *[]option, req *productspb.ListRequest) error {
 	if opts == nil {
 		return fmt.Errorf("opts is nil")
@@ -164,3 +488,286 @@ func applySorting(opts *[]option, req *productspb.ListRequest) error {
 
 	return nil
 }
+
+func toErrorPolicy(pb *productspb.ErrorPolicy) ErrorPolicy {
+	return ErrorPolicy{
+		Skip:               pb.Mode == productspb.ErrorPolicy_SKIP,
+		MaxRejected:        pb.MaxRejected,
+		MaxRejectedPercent: pb.MaxRejectedPercent,
+	}
+}
+
+// fetchOptions are the options of Fetch but wait and dry run.
+func fetchOptions(req *productspb.FetchRequest) ([]option, error) {
+	opts := []option{Options().WithForce(req.Force)}
+	if err := applyFeedOptions(&opts, req); err != nil {
+		return nil, fmt.Errorf("fetchOptions: %w", err)
+	}
+	if err := applyFetchConflict(&opts, req); err != nil {
+		return nil, fmt.Errorf("fetchOptions: %w", err)
+	}
+	if req.Source != "" {
+		opts = append(opts, Options().WithSource(req.Source))
+	}
+
+	return opts, nil
+}
+
+// applyFeedOptions applies how the feed is read and ingested.
+func applyFeedOptions(opts *[]option, req *productspb.FetchRequest) error {
+	if err := applyErrorPolicy(opts, req); err != nil {
+		return fmt.Errorf("applyFeedOptions: %w", err)
+	}
+	if err := applyFeedSchema(opts, req); err != nil {
+		return fmt.Errorf("applyFeedOptions: %w", err)
+	}
+	if err := applyFeedFormat(opts, req); err != nil {
+		return fmt.Errorf("applyFeedOptions: %w", err)
+	}
+	if err := applyArchive(opts, req); err != nil {
+		return fmt.Errorf("applyFeedOptions: %w", err)
+	}
+	if err := applySyncPolicy(opts, req); err != nil {
+		return fmt.Errorf("applyFeedOptions: %w", err)
+	}
+
+	return nil
+}
+
+func applyErrorPolicy(opts *[]option, req *productspb.FetchRequest) error {
+	if opts == nil {
+		return fmt.Errorf("opts is nil")
+	}
+
+	if req == nil || req.ErrorPolicy == nil {
+		return nil
+	}
+
+	errorPolicy, err := Options().WithErrorPolicy(toErrorPolicy(req.ErrorPolicy))
+	if err != nil {
+		return fmt.Errorf("applyErrorPolicy: %w", err)
+	}
+
+	optsVal := *opts
+	optsVal = append(optsVal, errorPolicy)
+
+	*opts = optsVal
+
+	return nil
+}
+
+var quoteModesFromPb = map[productspb.FeedSchema_Quotes]QuoteMode{
+	productspb.FeedSchema_STRICT: QuotesStrict,
+	productspb.FeedSchema_LAZY:   QuotesLazy,
+	productspb.FeedSchema_NONE:   QuotesNone,
+}
+
+var headerModesFromPb = map[productspb.FeedSchema_Header]HeaderMode{
+	productspb.FeedSchema_DETECT:  HeaderDetect,
+	productspb.FeedSchema_PRESENT: HeaderPresent,
+	productspb.FeedSchema_ABSENT:  HeaderAbsent,
+}
+
+func toFeedSchema(pb *productspb.FeedSchema) (FeedSchema, error) {
+	schema := FeedSchema{
+		Quotes:     quoteModesFromPb[pb.Quotes],
+		Header:     headerModesFromPb[pb.Header],
+		Columns:    pb.Columns,
+		RecordPath: pb.RecordPath,
+		Charset:    pb.Charset,
+	}
+
+	var err error
+	if schema.Delimiter, err = toRune("delimiter", pb.Delimiter); err != nil {
+		return schema, fmt.Errorf("toFeedSchema: %w", err)
+	}
+	if schema.Price.DecimalSeparator, err = toRune("decimal separator", pb.DecimalSeparator); err != nil {
+		return schema, fmt.Errorf("toFeedSchema: %w", err)
+	}
+	if schema.Price.GroupSeparator, err = toRune("grouping separator", pb.GroupSeparator); err != nil {
+		return schema, fmt.Errorf("toFeedSchema: %w", err)
+	}
+
+	return schema, nil
+}
+
+// toRune maps empty string to zero rune.
+func toRune(name, s string) (rune, error) {
+	if s == "" {
+		return 0, nil
+	}
+	r := []rune(s)
+	if len(r) != 1 {
+		return 0, fmt.Errorf("toRune: single character %s expected, got: %q", name, s)
+	}
+	return r[0], nil
+}
+
+func applyFeedSchema(opts *[]option, req *productspb.FetchRequest) error {
+	if opts == nil {
+		return fmt.Errorf("opts is nil")
+	}
+
+	if req == nil || req.Schema == nil {
+		return nil
+	}
+
+	schema, err := toFeedSchema(req.Schema)
+	if err != nil {
+		return fmt.Errorf("applyFeedSchema: %w", err)
+	}
+
+	schemaOpt, err := Options().WithFeedSchema(schema)
+	if err != nil {
+		return fmt.Errorf("applyFeedSchema: %w", err)
+	}
+
+	optsVal := *opts
+	optsVal = append(optsVal, schemaOpt)
+
+	*opts = optsVal
+
+	return nil
+}
+
+var feedFormatsFromPb = map[productspb.FeedFormat]FeedFormat{
+	productspb.FeedFormat_DETECT: FormatDetect,
+	productspb.FeedFormat_CSV:    FormatCSV,
+	productspb.FeedFormat_JSON:   FormatJSON,
+	productspb.FeedFormat_NDJSON: FormatNDJSON,
+	productspb.FeedFormat_XML:    FormatXML,
+}
+
+func applyFeedFormat(opts *[]option, req *productspb.FetchRequest) error {
+	if opts == nil {
+		return fmt.Errorf("opts is nil")
+	}
+
+	// source format stays unless overridden
+	if req == nil || req.Format == productspb.FeedFormat_DETECT {
+		return nil
+	}
+
+	format, ok := feedFormatsFromPb[req.Format]
+	if !ok {
+		return fmt.Errorf("applyFeedFormat: unknown feed format: %v", req.Format)
+	}
+
+	formatOpt, err := Options().WithFeedFormat(format)
+	if err != nil {
+		return fmt.Errorf("applyFeedFormat: %w", err)
+	}
+
+	optsVal := *opts
+	optsVal = append(optsVal, formatOpt)
+
+	*opts = optsVal
+
+	return nil
+}
+
+func toArchive(pb *productspb.Archive) ArchiveOptions {
+	return ArchiveOptions{
+		Pattern: pb.Pattern,
+		All:     pb.All,
+	}
+}
+
+func applyArchive(opts *[]option, req *productspb.FetchRequest) error {
+	if opts == nil {
+		return fmt.Errorf("opts is nil")
+	}
+
+	if req == nil || req.Archive == nil {
+		return nil
+	}
+
+	archiveOpt, err := Options().WithArchive(toArchive(req.Archive))
+	if err != nil {
+		return fmt.Errorf("applyArchive: %w", err)
+	}
+
+	optsVal := *opts
+	optsVal = append(optsVal, archiveOpt)
+
+	*opts = optsVal
+
+	return nil
+}
+
+var syncModesFromPb = map[productspb.SyncPolicy_Mode]SyncMode{
+	productspb.SyncPolicy_UPSERT: SyncUpsert,
+	productspb.SyncPolicy_FULL:   SyncFull,
+}
+
+func toSyncPolicy(pb *productspb.SyncPolicy) (SyncPolicy, error) {
+	mode, ok := syncModesFromPb[pb.Mode]
+	if !ok {
+		return SyncPolicy{}, fmt.Errorf("toSyncPolicy: unknown sync mode: %v", pb.Mode)
+	}
+
+	return SyncPolicy{
+		Mode:          mode,
+		MissedFetches: pb.MissedFetches,
+	}, nil
+}
+
+func applySyncPolicy(opts *[]option, req *productspb.FetchRequest) error {
+	if opts == nil {
+		return fmt.Errorf("opts is nil")
+	}
+
+	if req == nil || req.Sync == nil {
+		return nil
+	}
+
+	policy, err := toSyncPolicy(req.Sync)
+	if err != nil {
+		return fmt.Errorf("applySyncPolicy: %w", err)
+	}
+
+	syncOpt, err := Options().WithSyncPolicy(policy)
+	if err != nil {
+		return fmt.Errorf("applySyncPolicy: %w", err)
+	}
+
+	optsVal := *opts
+	optsVal = append(optsVal, syncOpt)
+
+	*opts = optsVal
+
+	return nil
+}
+
+var fetchConflictsFromPb = map[productspb.FetchRequest_OnConflict]FetchConflict{
+	productspb.FetchRequest_JOIN:   ConflictJoin,
+	productspb.FetchRequest_WAIT:   ConflictWait,
+	productspb.FetchRequest_REJECT: ConflictReject,
+}
+
+func applyFetchConflict(opts *[]option, req *productspb.FetchRequest) error {
+	if opts == nil {
+		return fmt.Errorf("opts is nil")
+	}
+
+	if req == nil {
+		return nil
+	}
+
+	conflict, ok := fetchConflictsFromPb[req.OnConflict]
+	if !ok {
+		return fmt.Errorf("applyFetchConflict: unknown fetch conflict policy: %v", req.OnConflict)
+	}
+
+	conflictOpt, err := Options().WithFetchConflict(conflict)
+	if err != nil {
+		return fmt.Errorf("applyFetchConflict: %w", err)
+	}
+
+	optsVal := *opts
+	optsVal = append(optsVal, conflictOpt)
+
+	*opts = optsVal
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package products
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/marknovikov/products-demo/pkg/productspb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *grpcServer) CreateSource(ctx context.Context, req *productspb.CreateSourceRequest) (*productspb.Source, error) {
+	src, err := toSource(req.Source)
+	if err != nil {
+		return &productspb.Source{}, status.Errorf(codes.InvalidArgument, "CreateSource: %v", err)
+	}
+
+	src, err = srv.s.CreateSource(ctx, src)
+	if err != nil {
+		return &productspb.Source{}, statusError("CreateSource", err)
+	}
+
+	return toSourcePb(src), nil
+}
+
+func (srv *grpcServer) UpdateSource(ctx context.Context, req *productspb.UpdateSourceRequest) (*productspb.Source, error) {
+	src, err := toSource(req.Source)
+	if err != nil {
+		return &productspb.Source{}, status.Errorf(codes.InvalidArgument, "UpdateSource: %v", err)
+	}
+
+	src, err = srv.s.UpdateSource(ctx, src)
+	if err != nil {
+		return &productspb.Source{}, statusError("UpdateSource", err)
+	}
+
+	return toSourcePb(src), nil
+}
+
+func (srv *grpcServer) GetSource(ctx context.Context, req *productspb.GetSourceRequest) (*productspb.Source, error) {
+	src, err := srv.s.GetSource(ctx, req.Name)
+	if err != nil {
+		return &productspb.Source{}, statusError("GetSource", err)
+	}
+
+	return toSourcePb(src), nil
+}
+
+func (srv *grpcServer) ListSources(ctx context.Context, req *productspb.ListSourcesRequest) (*productspb.ListSourcesResponse, error) {
+	resp := &productspb.ListSourcesResponse{}
+
+	ss, err := srv.s.ListSources(ctx, SourcesFilter{
+		Limit:    req.Limit,
+		LastName: req.LastName,
+	})
+	if err != nil {
+		return resp, statusError("ListSources", err)
+	}
+
+	resp.Sources = make([]*productspb.Source, len(ss))
+	for i, src := range ss {
+		resp.Sources[i] = toSourcePb(src)
+	}
+
+	return resp, nil
+}
+
+func (srv *grpcServer) DeleteSource(ctx context.Context, req *productspb.DeleteSourceRequest) (*productspb.DeleteSourceResponse, error) {
+	if err := srv.s.DeleteSource(ctx, req.Name); err != nil {
+		return &productspb.DeleteSourceResponse{}, statusError("DeleteSource", err)
+	}
+
+	return &productspb.DeleteSourceResponse{}, nil
+}
+
+func toSource(pb *productspb.Source) (Source, error) {
+	if pb == nil {
+		return Source{}, fmt.Errorf("toSource: source is required")
+	}
+
+	src := Source{
+		Name:     pb.Name,
+		URL:      pb.Url,
+		Headers:  pb.Headers,
+		Enabled:  pb.Enabled,
+		Schedule: pb.Schedule,
+	}
+
+	format, ok := feedFormatsFromPb[pb.Format]
+	if !ok {
+		return src, fmt.Errorf("toSource: unknown feed format: %v", pb.Format)
+	}
+	src.Format = format
+
+	if pb.Schema != nil {
+		schema, err := toFeedSchema(pb.Schema)
+		if err != nil {
+			return src, fmt.Errorf("toSource: %w", err)
+		}
+		src.Schema = schema
+	}
+	if pb.Archive != nil {
+		src.Archive = toArchive(pb.Archive)
+	}
+	if pb.ErrorPolicy != nil {
+		src.ErrorPolicy = toErrorPolicy(pb.ErrorPolicy)
+	}
+	if pb.Sync != nil {
+		policy, err := toSyncPolicy(pb.Sync)
+		if err != nil {
+			return src, fmt.Errorf("toSource: %w", err)
+		}
+		src.Sync = policy
+	}
+	if pb.Auth != nil {
+		src.Auth = toSourceAuth(pb.Auth)
+	}
+
+	return src, nil
+}
+
+func toSecret(pb *productspb.Secret) Secret {
+	return Secret{
+		Value:    pb.GetValue(),
+		File:     pb.GetFile(),
+		Redacted: pb.GetRedacted(),
+	}
+}
+
+func toSourceAuth(pb *productspb.SourceAuth) SourceAuth {
+	auth := SourceAuth{
+		Username:   pb.Username,
+		Password:   toSecret(pb.Password),
+		Token:      toSecret(pb.Token),
+		ClientCert: toSecret(pb.ClientCert),
+		ClientKey:  toSecret(pb.ClientKey),
+		CA:         toSecret(pb.Ca),
+	}
+	if len(pb.Headers) > 0 {
+		auth.Headers = make(map[string]Secret, len(pb.Headers))
+		for name, secret := range pb.Headers {
+			auth.Headers[name] = toSecret(secret)
+		}
+	}
+	return auth
+}
+
+func toSecretPb(s Secret) *productspb.Secret {
+	return &productspb.Secret{
+		Value:    s.Value,
+		File:     s.File,
+		Redacted: s.Redacted,
+	}
+}
+
+func toSourceAuthPb(a SourceAuth) *productspb.SourceAuth {
+	pb := &productspb.SourceAuth{
+		Username:   a.Username,
+		Password:   toSecretPb(a.Password),
+		Token:      toSecretPb(a.Token),
+		ClientCert: toSecretPb(a.ClientCert),
+		ClientKey:  toSecretPb(a.ClientKey),
+		Ca:         toSecretPb(a.CA),
+	}
+	if len(a.Headers) > 0 {
+		pb.Headers = make(map[string]*productspb.Secret, len(a.Headers))
+		for name, secret := range a.Headers {
+			pb.Headers[name] = toSecretPb(secret)
+		}
+	}
+	return pb
+}
+
+var quoteModesToPb = map[QuoteMode]productspb.FeedSchema_Quotes{
+	QuotesStrict: productspb.FeedSchema_STRICT,
+	QuotesLazy:   productspb.FeedSchema_LAZY,
+	QuotesNone:   productspb.FeedSchema_NONE,
+}
+
+var headerModesToPb = map[HeaderMode]productspb.FeedSchema_Header{
+	HeaderDetect:  productspb.FeedSchema_DETECT,
+	HeaderPresent: productspb.FeedSchema_PRESENT,
+	HeaderAbsent:  productspb.FeedSchema_ABSENT,
+}
+
+var feedFormatsToPb = map[FeedFormat]productspb.FeedFormat{
+	FormatDetect: productspb.FeedFormat_DETECT,
+	FormatCSV:    productspb.FeedFormat_CSV,
+	FormatJSON:   productspb.FeedFormat_JSON,
+	FormatNDJSON: productspb.FeedFormat_NDJSON,
+	FormatXML:    productspb.FeedFormat_XML,
+}
+
+var syncModesToPb = map[SyncMode]productspb.SyncPolicy_Mode{
+	SyncUpsert: productspb.SyncPolicy_UPSERT,
+	SyncFull:   productspb.SyncPolicy_FULL,
+}
+
+// fromRune maps zero rune to empty string.
+func fromRune(r rune) string {
+	if r == 0 {
+		return ""
+	}
+	return string(r)
+}
+
+func toSourcePb(src Source) *productspb.Source {
+	errorPolicyMode := productspb.ErrorPolicy_ABORT
+	if src.ErrorPolicy.Skip {
+		errorPolicyMode = productspb.ErrorPolicy_SKIP
+	}
+
+	return &productspb.Source{
+		Name:   src.Name,
+		Url:    src.URL,
+		Format: feedFormatsToPb[src.Format],
+		Schema: &productspb.FeedSchema{
+			Delimiter:        fromRune(src.Schema.Delimiter),
+			Quotes:           quoteModesToPb[src.Schema.Quotes],
+			Header:           headerModesToPb[src.Schema.Header],
+			Columns:          src.Schema.Columns,
+			RecordPath:       src.Schema.RecordPath,
+			Charset:          src.Schema.Charset,
+			DecimalSeparator: fromRune(src.Schema.Price.DecimalSeparator),
+			GroupSeparator:   fromRune(src.Schema.Price.GroupSeparator),
+		},
+		Archive: &productspb.Archive{
+			Pattern: src.Archive.Pattern,
+			All:     src.Archive.All,
+		},
+		Headers: src.Headers,
+		ErrorPolicy: &productspb.ErrorPolicy{
+			Mode:               errorPolicyMode,
+			MaxRejected:        src.ErrorPolicy.MaxRejected,
+			MaxRejectedPercent: src.ErrorPolicy.MaxRejectedPercent,
+		},
+		Sync: &productspb.SyncPolicy{
+			Mode:          syncModesToPb[src.Sync.Mode],
+			MissedFetches: src.Sync.MissedFetches,
+		},
+		Enabled:      src.Enabled,
+		CreatedAt:    toTimestampPb(src.CreatedAt),
+		UpdatedAt:    toTimestampPb(src.UpdatedAt),
+		Schedule:     src.Schedule,
+		NextRunAt:    toTimestampPb(src.NextRunAt),
+		LastRunAt:    toTimestampPb(src.LastRunAt),
+		LastJobId:    src.LastJobID,
+		LastRunError: src.LastRunError,
+		Auth:         toSourceAuthPb(src.Auth),
+	}
+}
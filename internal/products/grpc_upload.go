@@ -0,0 +1,78 @@
+package products
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/marknovikov/products-demo/pkg/productspb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (srv *grpcServer) UploadFeed(stream productspb.Products_UploadFeedServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "UploadFeed: %v", err)
+	}
+	header := req.GetHeader()
+	if header == nil {
+		return status.Errorf(codes.InvalidArgument, "UploadFeed: the first message must be the header")
+	}
+
+	var opts []option
+	feedOpts := &productspb.FetchRequest{
+		ErrorPolicy: header.ErrorPolicy,
+		Schema:      header.Schema,
+		Format:      header.Format,
+		Archive:     header.Archive,
+		Sync:        header.Sync,
+	}
+	if err := applyFeedOptions(&opts, feedOpts); err != nil {
+		return status.Errorf(codes.InvalidArgument, "UploadFeed: %v", err)
+	}
+	if header.Source != "" {
+		opts = append(opts, Options().WithSource(header.Source))
+	}
+
+	job, err := srv.s.UploadFeed(stream.Context(), header.Name, &uploadStream{stream}, opts...)
+	// the job finished unsuccessfully is returned as is, the way GetFetchJob does, along with its error and ingestion report
+	if err != nil && job.State != FetchJobStateFailed && job.State != FetchJobStateCanceled {
+		return statusError("UploadFeed", err)
+	}
+
+	return stream.SendAndClose(toFetchJobPb(job))
+}
+
+// uploadStream reads the upload following the header.
+type uploadStream struct {
+	stream productspb.Products_UploadFeedServer
+}
+
+func (u *uploadStream) Next() (UploadChunk, error) {
+	req, err := u.stream.Recv()
+	if err == io.EOF {
+		return UploadChunk{}, io.EOF
+	}
+	if err != nil {
+		return UploadChunk{}, fmt.Errorf("Next: %w", err)
+	}
+
+	switch payload := req.Payload.(type) {
+	case *productspb.UploadFeedRequest_Chunk:
+		return UploadChunk{Data: payload.Chunk}, nil
+	case *productspb.UploadFeedRequest_Products:
+		pp := payload.Products.GetProducts()
+		chunk := UploadChunk{Products: make([]UploadedProduct, len(pp))}
+		for i, p := range pp {
+			chunk.Products[i] = UploadedProduct{
+				Name:       p.Name,
+				Price:      p.Price,
+				ExternalID: p.ExternalId,
+				Currency:   p.Currency,
+			}
+		}
+		return chunk, nil
+	default:
+		return UploadChunk{}, fmt.Errorf("Next: header or empty message in the middle of the upload")
+	}
+}
@@ -0,0 +1,167 @@
+package products
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Subdirs of ServiceConfig.InboxDir files are moved to.
+const (
+	inboxProcessing = "processing"
+	inboxProcessed  = "processed"
+	inboxFailed     = "failed"
+)
+
+// inboxReport is written next to the processed or failed file.
+type inboxReport struct {
+	File       string          `json:"file"`
+	JobID      string          `json:"jobId,omitempty"`
+	State      FetchJobState   `json:"state,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	StartedAt  time.Time       `json:"startedAt"`
+	FinishedAt time.Time       `json:"finishedAt"`
+	Report     IngestionReport `json:"report"`
+}
+
+// runInbox ingests files dropped into the inbox dir one by one. Every instance may watch the same dir,
+// a file is claimed by moving it into processing/ first, so it's ingested once.
+// Files are fetched by their file:// url with default options, format is told by the extension,
+// then moved into processed/ or failed/ along with a .report.json sidecar.
+// Hidden files and ones still being written are skipped.
+func (s *service) runInbox(ctx context.Context) {
+	for _, dir := range []string{inboxProcessing, inboxProcessed, inboxFailed} {
+		if err := os.MkdirAll(filepath.Join(s.cfg.InboxDir, dir), 0o755); err != nil {
+			log.Printf("inbox: %v", err)
+			return
+		}
+	}
+
+	ticker := time.NewTicker(s.cfg.InboxPoll)
+	defer ticker.Stop()
+
+	for {
+		if err := s.inboxTick(ctx); err != nil {
+			log.Printf("inbox: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *service) inboxTick(ctx context.Context) error {
+	files, err := ioutil.ReadDir(s.cfg.InboxDir)
+	if err != nil {
+		return fmt.Errorf("inboxTick: %w", err)
+	}
+
+	now := time.Now()
+	for _, info := range files {
+		if ctx.Err() != nil {
+			return nil
+		}
+		name := info.Name()
+		if !info.Mode().IsRegular() || inboxSkipped(name) {
+			continue
+		}
+		// writer may still be appending to the file
+		if now.Sub(info.ModTime()) < s.cfg.InboxSettle {
+			continue
+		}
+
+		if err := s.ingestInboxFile(ctx, name); err != nil {
+			log.Printf("inbox: %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
+
+// inboxSkipped tells files being uploaded under temporary names.
+func inboxSkipped(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".tmp") || strings.HasSuffix(name, ".part")
+}
+
+func (s *service) ingestInboxFile(ctx context.Context, name string) error {
+	inbox := filepath.Join(s.cfg.InboxDir, name)
+	processing := filepath.Join(s.cfg.InboxDir, inboxProcessing, name)
+
+	claimed, err := moveNoReplace(inbox, processing)
+	if err != nil {
+		return fmt.Errorf("ingestInboxFile: %w", err)
+	}
+	if !claimed {
+		// taken by another instance, or the file of the same name is still being processed
+		return nil
+	}
+
+	report := inboxReport{File: name, StartedAt: time.Now().UTC()}
+
+	path, err := filepath.Abs(processing)
+	if err != nil {
+		return fmt.Errorf("ingestInboxFile: %w", err)
+	}
+	job, err := s.Fetch(ctx, (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String(), Options().WithWait(true))
+	if ctx.Err() != nil {
+		// shutting down, the file is picked up again on the next start
+		if _, err := moveNoReplace(processing, inbox); err != nil {
+			return fmt.Errorf("ingestInboxFile: %w", err)
+		}
+		return nil
+	}
+
+	report.FinishedAt = time.Now().UTC()
+	report.JobID = job.ID
+	report.State = job.State
+	report.Report = job.Report
+	dir := inboxProcessed
+	if err != nil {
+		report.Error = err.Error()
+		dir = inboxFailed
+	}
+
+	// processed files of the same name are kept apart by the time they were taken
+	done := filepath.Join(s.cfg.InboxDir, dir, report.StartedAt.Format("20060102T150405.000Z")+"-"+name)
+	if err := os.Rename(processing, done); err != nil {
+		return fmt.Errorf("ingestInboxFile: %w", err)
+	}
+
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("ingestInboxFile: %w", err)
+	}
+	if err := ioutil.WriteFile(done+".report.json", b, 0o644); err != nil {
+		return fmt.Errorf("ingestInboxFile: %w", err)
+	}
+
+	log.Printf("inbox: %s moved to %s", name, dir)
+
+	return nil
+}
+
+// moveNoReplace moves the file unless the target exists, only one of concurrent callers succeeds.
+// False is returned if the source is gone or the target exists.
+func moveNoReplace(from, to string) (bool, error) {
+	if err := os.Link(from, to); err != nil {
+		if os.IsExist(err) || os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("moveNoReplace: %w", err)
+	}
+	if err := os.Remove(from); err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("moveNoReplace: %w", err)
+	}
+
+	return true, nil
+}
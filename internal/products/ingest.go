@@ -0,0 +1,375 @@
+package products
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// fetchRun is a single fetch job run state.
+type fetchRun struct {
+	jobID string
+	path  string
+	// registered source name, empty if fetched by url
+	source   string
+	opts     []option
+	progress *fetchProgress
+	// set for dry runs, nothing is written then
+	diff *FetchDiff
+	// feed version of the last fetch and the one being fetched
+	prev, version FeedVersion
+	// set if the feed is pushed by the caller, uploads are never skipped as unchanged
+	upload FeedUpload
+	// set once a row is rejected before its product name is read, full sync can't tell missing products then
+	unnamedRejected bool
+}
+
+// fetch streams the feed into the storage batch by batch.
+// Parsing runs ahead of writing by at most ServiceConfig.BatchQueue batches,
+// so memory stays bounded regardless of the feed size.
+// Batches written before a failure stay written.
+func (s *service) fetch(ctx context.Context, run *fetchRun) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	opts := applyOptions(run.opts)
+	// dry run shows the difference with the catalog, not with the last fetch
+	if run.diff == nil && run.upload == nil && !opts.force {
+		prev, err := s.storage.FindFeedVersion(ctx, run.feedKey())
+		if err != nil {
+			return fmt.Errorf("fetch: %w", err)
+		}
+		run.prev = prev
+	}
+
+	batches := make(chan []FeedRow, s.cfg.BatchQueue)
+	listErr := make(chan error, 1)
+
+	go func() {
+		defer close(batches)
+
+		listErr <- s.listBatches(ctx, run, batches)
+	}()
+
+	write := s.writeBatch
+	if run.diff != nil {
+		write = s.diffBatch
+	}
+
+	for batch := range batches {
+		if err := write(ctx, run, batch); err != nil {
+			cancel()
+			<-listErr
+
+			return fmt.Errorf("fetch: %w", err)
+		}
+	}
+
+	err := <-listErr
+	if skipped := feedSkipReason(err); skipped != "" {
+		run.progress.update(func(r *IngestionReport) {
+			r.Skipped = skipped
+		})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+
+	if err := opts.errorPolicy.check(run.progress.snapshot(), true); err != nil {
+		return fmt.Errorf("fetch: %w", err)
+	}
+
+	// only a feed read in full tells which products are missing
+	if opts.sync.Mode == SyncFull && run.diff == nil && !run.unnamedRejected {
+		archived, err := s.storage.ArchiveMissingProducts(ctx, run.origin(), opts.sync)
+		run.progress.update(func(r *IngestionReport) {
+			r.Archived += archived
+		})
+		if err != nil {
+			return fmt.Errorf("fetch: %w", err)
+		}
+	}
+
+	// the feed is skipped next time only if it is ingested in full now
+	if run.diff == nil && run.upload == nil {
+		if err := s.storage.SaveFeedVersion(ctx, run.feedKey(), run.version); err != nil {
+			return fmt.Errorf("fetch: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// feedSkipReason tells why the feed is not ingested, empty if it is.
+func feedSkipReason(err error) string {
+	switch {
+	case goErrors.Is(err, errFeedNotModified):
+		return "not modified since the last fetch"
+	case goErrors.Is(err, errFeedUnchanged):
+		return "same content as the last fetch"
+	default:
+		return ""
+	}
+}
+
+func (run *fetchRun) origin() PriceOrigin {
+	source := run.source
+	if source == "" {
+		source = run.path
+	}
+
+	return PriceOrigin{
+		Source: source,
+		JobID:  run.jobID,
+	}
+}
+
+func (run *fetchRun) feedKey() string {
+	return feedKey(run.source, run.path)
+}
+
+func (s *service) listBatches(ctx context.Context, run *fetchRun, batches chan<- []FeedRow) error {
+	send := func(batch []FeedRow) error {
+		select {
+		case batches <- batch:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	batch := make([]FeedRow, 0, s.cfg.BatchSize)
+
+	fn := func(row FeedRow) error {
+		batch = append(batch, row)
+		if len(batch) < s.cfg.BatchSize {
+			return nil
+		}
+
+		if err := send(batch); err != nil {
+			return err
+		}
+		batch = make([]FeedRow, 0, s.cfg.BatchSize)
+
+		return nil
+	}
+
+	list := s.download
+	if run.upload != nil {
+		list = s.listUpload
+	}
+	if err := list(ctx, run, fn); err != nil {
+		return fmt.Errorf("listBatches: %w", err)
+	}
+
+	if len(batch) > 0 {
+		if err := send(batch); err != nil {
+			return fmt.Errorf("listBatches: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) download(ctx context.Context, run *fetchRun, fn func(row FeedRow) error) error {
+	opts := append([]option{Options().withFeedVersion(run.prev)}, run.opts...)
+
+	res, err := s.client.List(ctx, run.path, fn, opts...)
+	run.version = res.Version
+	run.progress.update(func(r *IngestionReport) {
+		r.Retries = res.Retries
+		r.Breaker = res.Breaker
+	})
+	if err != nil {
+		return fmt.Errorf("download: %w", err)
+	}
+
+	return nil
+}
+
+// writeBatch writes parsed rows, records rejected ones and tells whether the error policy allows to go on.
+func (s *service) writeBatch(ctx context.Context, run *fetchRun, batch []FeedRow) error {
+	var (
+		// batch positions of rows sent to storage
+		valid []int
+		pp    []Product
+	)
+	for i, row := range batch {
+		if row.Err == nil {
+			valid = append(valid, i)
+			pp = append(pp, row.Product)
+		}
+	}
+
+	res, err := s.storage.UpdateProducts(ctx, pp, run.origin())
+	reasons := make(map[int]string, len(res.Rejected))
+	for idx, reason := range res.Rejected {
+		reasons[valid[idx]] = reason
+	}
+
+	// kept in feed order, lines alone do not order rows of different archive entries
+	var (
+		rejected []RowError
+		// products of rejected rows are still listed by the feed
+		listed []string
+	)
+	for i, row := range batch {
+		reason, ok := reasons[i]
+		if row.Err != nil {
+			reason, ok = row.Err.Error(), true
+		}
+		if !ok {
+			continue
+		}
+		if name := row.Product.Name; strings.TrimSpace(name) != "" && utf8.ValidString(name) {
+			listed = append(listed, name)
+		} else {
+			run.unnamedRejected = true
+		}
+		rejected = append(rejected, RowError{
+			Line:   row.Line,
+			Record: row.Record,
+			Reason: reason,
+			Entry:  row.Entry,
+		})
+	}
+
+	var report IngestionReport
+	run.progress.update(func(r *IngestionReport) {
+		r.Parsed += uint32(len(batch))
+		r.addUpdateResult(res)
+		for _, e := range rejected {
+			r.reject(e, s.cfg.MaxReportErrors)
+		}
+		report = *r
+	})
+
+	if err != nil {
+		return fmt.Errorf("writeBatch: %w", err)
+	}
+
+	if len(rejected) > 0 {
+		if err := s.storage.InsertFetchJobErrors(ctx, run.jobID, rejected); err != nil {
+			return fmt.Errorf("writeBatch: %w", err)
+		}
+	}
+
+	if applyOptions(run.opts).sync.Mode == SyncFull {
+		if err := s.storage.KeepListed(ctx, listed, run.origin()); err != nil {
+			return fmt.Errorf("writeBatch: %w", err)
+		}
+	}
+
+	if err := applyOptions(run.opts).errorPolicy.check(report, false); err != nil {
+		return fmt.Errorf("writeBatch: %w", err)
+	}
+
+	return nil
+}
+
+// diffBatch compares parsed rows with stored products the way writeBatch would write them.
+func (s *service) diffBatch(ctx context.Context, run *fetchRun, batch []FeedRow) error {
+	var (
+		names    []string
+		rejected []RowError
+		// rows compared, the first of the same name only, as UpdateProducts writes them
+		compared = make(map[int]bool, len(batch))
+		seen     = make(map[string]bool, len(batch))
+	)
+	for i, row := range batch {
+		reason := ""
+		switch {
+		case row.Err != nil:
+			reason = row.Err.Error()
+		case seen[row.Product.Name]:
+			reason = duplicateNameReason(row.Product.Name)
+		}
+		if reason != "" {
+			rejected = append(rejected, RowError{
+				Line:   row.Line,
+				Record: row.Record,
+				Reason: reason,
+				Entry:  row.Entry,
+			})
+			continue
+		}
+		seen[row.Product.Name] = true
+		compared[i] = true
+		names = append(names, row.Product.Name)
+	}
+
+	stored, err := s.storage.FindProductsByName(ctx, names)
+	if err != nil {
+		return fmt.Errorf("diffBatch: %w", err)
+	}
+	current := make(map[string]Product, len(stored))
+	for _, p := range stored {
+		current[p.Name] = p
+	}
+
+	var res UpdateResult
+	for i, row := range batch {
+		if !compared[i] {
+			continue
+		}
+
+		// stored prices are rounded the same way
+		p := row.Product
+		p.Price = p.Price.Round(2)
+
+		cur, ok := current[p.Name]
+		if !ok {
+			res.Inserted++
+			if len(run.diff.New) < s.cfg.MaxDiffItems {
+				run.diff.New = append(run.diff.New, p)
+			}
+			continue
+		}
+
+		cmp := p.Price.Cmp(cur.Price)
+		if cmp == 0 {
+			res.Unchanged++
+			continue
+		}
+		res.Repriced++
+
+		p.ID = cur.ID
+		delta := PriceDelta{
+			Product:  p,
+			OldPrice: cur.Price,
+			Delta:    p.Price.Sub(cur.Price),
+		}
+		if cmp > 0 {
+			run.diff.Increased++
+			if len(run.diff.Increases) < s.cfg.MaxDiffItems {
+				run.diff.Increases = append(run.diff.Increases, delta)
+			}
+		} else {
+			run.diff.Decreased++
+			if len(run.diff.Decreases) < s.cfg.MaxDiffItems {
+				run.diff.Decreases = append(run.diff.Decreases, delta)
+			}
+		}
+	}
+
+	var report IngestionReport
+	run.progress.update(func(r *IngestionReport) {
+		r.Parsed += uint32(len(batch))
+		r.Inserted += res.Inserted
+		r.Repriced += res.Repriced
+		r.Unchanged += res.Unchanged
+		for _, e := range rejected {
+			r.reject(e, s.cfg.MaxReportErrors)
+		}
+		report = *r
+	})
+
+	if err := applyOptions(run.opts).errorPolicy.check(report, false); err != nil {
+		return fmt.Errorf("diffBatch: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,406 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestFetch(t *testing.T) {
+	tests := []struct {
+		name      string
+		stored    []Product
+		refuse    map[string]string
+		batchSize int
+		rows      []FeedRow
+		opts      []option
+		wantErr   bool
+		// report without errors, they are checked by line
+		report   IngestionReport
+		rejected map[uint32]string
+		prices   map[string]string
+		// names kept listed and archiving runs by full sync
+		kept     []string
+		archives int
+	}{
+		{
+			name:      "batches",
+			batchSize: 2,
+			rows:      []FeedRow{feedRow(1, "a", "1"), feedRow(2, "b", "2"), feedRow(3, "c", "3")},
+			report:    IngestionReport{Parsed: 3, Written: 3, Inserted: 3},
+			prices:    map[string]string{"a": "1", "b": "2", "c": "3"},
+		},
+		{
+			name:      "repriced and unchanged",
+			stored:    []Product{{Name: "a", Price: decimal.RequireFromString("1")}, {Name: "b", Price: decimal.RequireFromString("2")}},
+			batchSize: 2,
+			rows:      []FeedRow{feedRow(1, "a", "1"), feedRow(2, "b", "3"), feedRow(3, "c", "1")},
+			report:    IngestionReport{Parsed: 3, Written: 2, Inserted: 1, Repriced: 1, Unchanged: 1},
+			prices:    map[string]string{"a": "1", "b": "3", "c": "1"},
+		},
+		{
+			name:      "same name in another batch",
+			batchSize: 2,
+			rows:      []FeedRow{feedRow(1, "a", "1"), feedRow(2, "b", "1"), feedRow(3, "a", "2")},
+			report:    IngestionReport{Parsed: 3, Written: 3, Inserted: 2, Repriced: 1},
+			prices:    map[string]string{"a": "2", "b": "1"},
+		},
+		{
+			name:      "same name within a batch",
+			batchSize: 3,
+			rows:      []FeedRow{feedRow(1, "a", "1"), feedRow(2, "a", "2"), feedRow(3, "b", "1")},
+			opts:      []option{mustOption(Options().WithErrorPolicy(ErrorPolicy{Skip: true}))},
+			report:    IngestionReport{Parsed: 3, Written: 2, Inserted: 2, Rejected: 1},
+			rejected:  map[uint32]string{2: duplicateNameReason("a")},
+			prices:    map[string]string{"a": "1", "b": "1"},
+		},
+		{
+			name:      "storage refusals mapped past rows failed to parse",
+			refuse:    map[string]string{"b": "refused"},
+			batchSize: 4,
+			rows:      []FeedRow{badRow(1, "x", "bad price"), feedRow(2, "a", "1"), feedRow(3, "b", "1"), feedRow(4, "c", "1")},
+			opts:      []option{mustOption(Options().WithErrorPolicy(ErrorPolicy{Skip: true}))},
+			report:    IngestionReport{Parsed: 4, Written: 2, Inserted: 2, Rejected: 2},
+			rejected:  map[uint32]string{1: "bad price", 3: "refused"},
+			prices:    map[string]string{"a": "1", "c": "1"},
+		},
+		{
+			name:      "aborted on the first rejected row",
+			batchSize: 2,
+			rows:      []FeedRow{feedRow(1, "a", "1"), badRow(2, "b", "bad price"), feedRow(3, "c", "1"), feedRow(4, "d", "1")},
+			wantErr:   true,
+			report:    IngestionReport{Parsed: 2, Written: 1, Inserted: 1, Rejected: 1},
+			rejected:  map[uint32]string{2: "bad price"},
+			prices:    map[string]string{"a": "1"},
+		},
+		{
+			name:      "aborted over max rejected",
+			batchSize: 1,
+			rows:      []FeedRow{badRow(1, "x", "bad price"), feedRow(2, "a", "1"), badRow(3, "y", "bad price"), feedRow(4, "b", "1")},
+			opts:      []option{mustOption(Options().WithErrorPolicy(ErrorPolicy{Skip: true, MaxRejected: 1}))},
+			wantErr:   true,
+			report:    IngestionReport{Parsed: 3, Written: 1, Inserted: 1, Rejected: 2},
+			rejected:  map[uint32]string{1: "bad price", 3: "bad price"},
+			prices:    map[string]string{"a": "1"},
+		},
+		{
+			name:      "within max rejected percent",
+			batchSize: 2,
+			rows:      []FeedRow{feedRow(1, "a", "1"), badRow(2, "x", "bad price"), feedRow(3, "b", "1"), feedRow(4, "c", "1")},
+			opts:      []option{mustOption(Options().WithErrorPolicy(ErrorPolicy{Skip: true, MaxRejectedPercent: 30}))},
+			report:    IngestionReport{Parsed: 4, Written: 3, Inserted: 3, Rejected: 1},
+			rejected:  map[uint32]string{2: "bad price"},
+			prices:    map[string]string{"a": "1", "b": "1", "c": "1"},
+		},
+		{
+			name:      "over max rejected percent once complete",
+			batchSize: 2,
+			rows:      []FeedRow{badRow(1, "x", "bad price"), badRow(2, "y", "bad price"), feedRow(3, "a", "1"), feedRow(4, "b", "1")},
+			opts:      []option{mustOption(Options().WithErrorPolicy(ErrorPolicy{Skip: true, MaxRejectedPercent: 30}))},
+			wantErr:   true,
+			report:    IngestionReport{Parsed: 4, Written: 2, Inserted: 2, Rejected: 2},
+			rejected:  map[uint32]string{1: "bad price", 2: "bad price"},
+			prices:    map[string]string{"a": "1", "b": "1"},
+		},
+		{
+			name:      "full sync",
+			refuse:    map[string]string{"b": "refused"},
+			batchSize: 2,
+			rows:      []FeedRow{feedRow(1, "a", "1"), feedRow(2, "b", "1"), badRow(3, "c", "bad price")},
+			opts: []option{
+				mustOption(Options().WithErrorPolicy(ErrorPolicy{Skip: true})),
+				mustOption(Options().WithSyncPolicy(SyncPolicy{Mode: SyncFull})),
+			},
+			report:   IngestionReport{Parsed: 3, Written: 1, Inserted: 1, Rejected: 2, Archived: 1},
+			rejected: map[uint32]string{2: "refused", 3: "bad price"},
+			prices:   map[string]string{"a": "1"},
+			kept:     []string{"b", "c"},
+			archives: 1,
+		},
+		{
+			name:      "full sync with unnamed rejected row",
+			batchSize: 2,
+			rows:      []FeedRow{feedRow(1, "a", "1"), badRow(2, "", "no name")},
+			opts: []option{
+				mustOption(Options().WithErrorPolicy(ErrorPolicy{Skip: true})),
+				mustOption(Options().WithSyncPolicy(SyncPolicy{Mode: SyncFull})),
+			},
+			report:   IngestionReport{Parsed: 2, Written: 1, Inserted: 1, Rejected: 1},
+			rejected: map[uint32]string{2: "no name"},
+			prices:   map[string]string{"a": "1"},
+		},
+		{
+			name:      "full sync aborted",
+			batchSize: 2,
+			rows:      []FeedRow{feedRow(1, "a", "1"), badRow(2, "b", "bad price")},
+			opts:      []option{mustOption(Options().WithSyncPolicy(SyncPolicy{Mode: SyncFull}))},
+			wantErr:   true,
+			report:    IngestionReport{Parsed: 2, Written: 1, Inserted: 1, Rejected: 1},
+			rejected:  map[uint32]string{2: "bad price"},
+			prices:    map[string]string{"a": "1"},
+			kept:      []string{"b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newMemStorage(tt.stored...)
+			st.refuse = tt.refuse
+			s := &service{
+				client:  &feedClient{rows: tt.rows},
+				storage: st,
+				cfg:     ServiceConfig{BatchSize: tt.batchSize, BatchQueue: 1, MaxReportErrors: 10},
+			}
+			run := &fetchRun{
+				jobID:    "job",
+				path:     "https://feeds.example.com/feed.csv",
+				opts:     tt.opts,
+				progress: &fetchProgress{},
+			}
+
+			err := s.fetch(context.Background(), run)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			report := run.progress.snapshot()
+			checkRejected(t, report.Errors, tt.rejected)
+			report.Errors = nil
+			if !reflect.DeepEqual(report, tt.report) {
+				t.Errorf("report = %+v, want %+v", report, tt.report)
+			}
+			st.checkPrices(t, tt.prices)
+			if !reflect.DeepEqual(st.kept, tt.kept) || st.archives != tt.archives {
+				t.Errorf("kept %v, archived %d times, want %v, %d", st.kept, st.archives, tt.kept, tt.archives)
+			}
+		})
+	}
+}
+
+func checkRejected(t *testing.T, ee []RowError, want map[uint32]string) {
+	t.Helper()
+	got := make(map[uint32]string, len(ee))
+	for _, e := range ee {
+		got[e.Line] = e.Reason
+	}
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rejected rows = %v, want %v", got, want)
+	}
+}
+
+func feedRow(line uint32, name, price string) FeedRow {
+	return FeedRow{
+		Line:    line,
+		Record:  []string{name, price},
+		Product: Product{Name: name, Price: decimal.RequireFromString(price)},
+	}
+}
+
+// badRow is failed to parse after its product name is read, unnamed if name is empty.
+func badRow(line uint32, name, reason string) FeedRow {
+	return FeedRow{
+		Line:    line,
+		Record:  []string{name, "?"},
+		Product: Product{Name: name},
+		Err:     errors.New(reason),
+	}
+}
+
+func mustOption(o option, err error) option {
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// feedClient lists the same rows whatever the url is.
+type feedClient struct {
+	Client
+	rows []FeedRow
+}
+
+func (c *feedClient) List(ctx context.Context, url string, fn func(row FeedRow) error, opts ...option) (ListResult, error) {
+	for _, row := range c.rows {
+		if err := fn(row); err != nil {
+			return ListResult{}, err
+		}
+	}
+	return ListResult{}, nil
+}
+
+// memStorage keeps products in memory, methods the tests do not need are left to the nil Storage.
+type memStorage struct {
+	Storage
+
+	mu       sync.Mutex
+	products map[string]Product
+	// names UpdateProducts refuses, with the reasons
+	refuse map[string]string
+	errors []RowError
+	kept   []string
+	// ArchiveMissingProducts calls, each archives a single product
+	archives int
+}
+
+func newMemStorage(pp ...Product) *memStorage {
+	m := &memStorage{products: make(map[string]Product)}
+	for _, p := range pp {
+		m.products[p.Name] = p
+	}
+	return m
+}
+
+// UpdateProducts writes the first product of the same name only, as the mongo storage does.
+func (m *memStorage) UpdateProducts(ctx context.Context, pp []Product, origin PriceOrigin) (UpdateResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var res UpdateResult
+	seen := make(map[string]bool, len(pp))
+	for i, p := range pp {
+		if seen[p.Name] {
+			res.reject(i, duplicateNameReason(p.Name))
+			continue
+		}
+		seen[p.Name] = true
+		if reason, ok := m.refuse[p.Name]; ok {
+			res.reject(i, reason)
+			continue
+		}
+
+		cur, ok := m.products[p.Name]
+		switch {
+		case !ok:
+			res.Inserted++
+		case cur.Price.Equal(p.Price):
+			res.Unchanged++
+		default:
+			res.Repriced++
+		}
+		m.products[p.Name] = p
+	}
+
+	return res, nil
+}
+
+func (m *memStorage) FindProductsByName(ctx context.Context, names []string) ([]Product, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var pp []Product
+	for _, name := range names {
+		if p, ok := m.products[name]; ok {
+			pp = append(pp, p)
+		}
+	}
+	return pp, nil
+}
+
+func (m *memStorage) InsertFetchJobErrors(ctx context.Context, id string, ee []RowError) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.errors = append(m.errors, ee...)
+	return nil
+}
+
+func (m *memStorage) KeepListed(ctx context.Context, names []string, origin PriceOrigin) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.kept = append(m.kept, names...)
+	return nil
+}
+
+func (m *memStorage) ArchiveMissingProducts(ctx context.Context, origin PriceOrigin, policy SyncPolicy) (uint32, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.archives++
+	return 1, nil
+}
+
+func (m *memStorage) FindFeedVersion(ctx context.Context, key string) (FeedVersion, error) {
+	return FeedVersion{}, nil
+}
+
+func (m *memStorage) SaveFeedVersion(ctx context.Context, key string, v FeedVersion) error {
+	return nil
+}
+
+func (m *memStorage) checkPrices(t *testing.T, want map[string]string) {
+	t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.products) != len(want) {
+		t.Errorf("stored %d products, want %d", len(m.products), len(want))
+	}
+	for name, price := range want {
+		if p, ok := m.products[name]; !ok || !p.Price.Equal(decimal.RequireFromString(price)) {
+			t.Errorf("stored %s = %v, want price %s", name, p.Price, price)
+		}
+	}
+}
+
+func TestFetchDiff(t *testing.T) {
+	st := newMemStorage(
+		Product{Name: "a", Price: decimal.RequireFromString("1")},
+		Product{Name: "b", Price: decimal.RequireFromString("2")},
+		Product{Name: "c", Price: decimal.RequireFromString("3")},
+	)
+	s := &service{
+		client: &feedClient{rows: []FeedRow{
+			// rounded to the stored price
+			feedRow(1, "a", "1.001"),
+			feedRow(2, "b", "1.5"),
+			feedRow(3, "c", "4"),
+			feedRow(4, "d", "5"),
+			// compared once, as written once
+			feedRow(5, "d", "6"),
+			badRow(6, "e", "bad price"),
+		}},
+		storage: st,
+		cfg:     ServiceConfig{BatchSize: 3, BatchQueue: 1, MaxReportErrors: 10, MaxDiffItems: 10},
+	}
+	run := &fetchRun{
+		jobID:    "job",
+		path:     "https://feeds.example.com/feed.csv",
+		opts:     []option{mustOption(Options().WithErrorPolicy(ErrorPolicy{Skip: true}))},
+		progress: &fetchProgress{},
+		diff:     &FetchDiff{},
+	}
+
+	if err := s.fetch(context.Background(), run); err != nil {
+		t.Fatalf("fetch() error = %v", err)
+	}
+
+	report := run.progress.snapshot()
+	checkRejected(t, report.Errors, map[uint32]string{5: duplicateNameReason("d"), 6: "bad price"})
+	report.Errors = nil
+	if want := (IngestionReport{Parsed: 6, Inserted: 1, Repriced: 2, Unchanged: 1, Rejected: 2}); !reflect.DeepEqual(report, want) {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+
+	if run.diff.Increased != 1 || len(run.diff.Increases) != 1 || run.diff.Increases[0].Product.Name != "c" ||
+		!run.diff.Increases[0].Delta.Equal(decimal.RequireFromString("1")) {
+		t.Errorf("increases = %d %+v, want c by 1", run.diff.Increased, run.diff.Increases)
+	}
+	if run.diff.Decreased != 1 || len(run.diff.Decreases) != 1 || run.diff.Decreases[0].Product.Name != "b" ||
+		!run.diff.Decreases[0].Delta.Equal(decimal.RequireFromString("-0.5")) {
+		t.Errorf("decreases = %d %+v, want b by -0.5", run.diff.Decreased, run.diff.Decreases)
+	}
+	if len(run.diff.New) != 1 || run.diff.New[0].Name != "d" || !run.diff.New[0].Price.Equal(decimal.RequireFromString("5")) {
+		t.Errorf("new = %+v, want d at 5", run.diff.New)
+	}
+
+	// nothing is written
+	st.checkPrices(t, map[string]string{"a": "1", "b": "2", "c": "3"})
+}
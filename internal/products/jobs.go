@@ -0,0 +1,355 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// jobRunner tracks fetch jobs running on this instance.
+type jobRunner struct {
+	ctx      context.Context
+	shutdown context.CancelFunc
+	wg       sync.WaitGroup
+
+	mu      sync.Mutex
+	running map[string]*runningJob
+}
+
+type runningJob struct {
+	cancel          context.CancelFunc
+	cancelRequested bool
+	// why the job is stopped by the service itself
+	abortErr error
+}
+
+func newJobRunner() *jobRunner {
+	ctx, shutdown := context.WithCancel(context.Background())
+
+	return &jobRunner{
+		ctx:      ctx,
+		shutdown: shutdown,
+		running:  make(map[string]*runningJob),
+	}
+}
+
+// start returns a channel closed once the job is done.
+func (r *jobRunner) start(id string, run func(ctx context.Context)) <-chan struct{} {
+	ctx, cancel := context.WithCancel(r.ctx)
+	done := make(chan struct{})
+
+	r.mu.Lock()
+	r.running[id] = &runningJob{cancel: cancel}
+	r.mu.Unlock()
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer close(done)
+		defer func() {
+			r.mu.Lock()
+			delete(r.running, id)
+			r.mu.Unlock()
+
+			cancel()
+		}()
+
+		run(ctx)
+	}()
+
+	return done
+}
+
+// cancel is a no-op for jobs not running on this instance.
+func (r *jobRunner) cancel(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.running[id]
+	if !ok {
+		return
+	}
+	job.cancelRequested = true
+	job.cancel()
+}
+
+// abort stops the job which must not go on, it fails with the given reason.
+func (r *jobRunner) abort(id string, reason error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.running[id]
+	if !ok {
+		return
+	}
+	job.abortErr = reason
+	job.cancel()
+}
+
+func (r *jobRunner) aborted(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.running[id]
+	if !ok {
+		return nil
+	}
+	return job.abortErr
+}
+
+func (r *jobRunner) cancelRequested(id string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job, ok := r.running[id]
+	return ok && job.cancelRequested
+}
+
+func (r *jobRunner) closed() bool {
+	return r.ctx.Err() != nil
+}
+
+func (r *jobRunner) stop() {
+	r.shutdown()
+	r.wg.Wait()
+}
+
+func (s *service) runFetchJob(ctx context.Context, job FetchJob, opts []option) {
+	// released once the job state is recorded, so whoever waits for the lock finds the job finished
+	defer func() {
+		if err := s.releaseFetchLocks(job); err != nil {
+			log.Printf("fetch job %s: %v", job.ID, err)
+		}
+	}()
+	defer func() {
+		if err := s.finishFetchJob(job); err != nil {
+			log.Printf("fetch job %s: %v", job.ID, err)
+		}
+	}()
+
+	run := &fetchRun{
+		jobID:    job.ID,
+		path:     job.URL,
+		source:   job.Source,
+		opts:     opts,
+		progress: &fetchProgress{},
+		upload:   applyOptions(opts).upload,
+	}
+
+	// pending job queued for a worker heartbeats too, so it keeps the fetch lock and is not taken for gone
+	hbCtx, stopHeartbeat := context.WithCancel(ctx)
+	hbDone := make(chan struct{})
+	go func() {
+		defer close(hbDone)
+		s.heartbeatFetchJob(hbCtx, job, run.progress)
+	}()
+	defer func() {
+		stopHeartbeat()
+		<-hbDone
+	}()
+
+	release, err := s.fetchWorker(ctx, run)
+	if err != nil {
+		job.State, job.Error = s.fetchJobFailure(job.ID, err)
+		return
+	}
+	defer release()
+
+	started, err := s.startFetchJob(job.ID)
+	if err != nil {
+		job.State = FetchJobStateFailed
+		job.Error = err.Error()
+		return
+	}
+	if !started {
+		job.State = FetchJobStateCanceled
+		return
+	}
+
+	fetchCtx := ctx
+	if s.cfg.FetchTimeout > 0 {
+		var cancel context.CancelFunc
+		fetchCtx, cancel = context.WithTimeout(ctx, s.cfg.FetchTimeout)
+		defer cancel()
+	}
+
+	err = s.fetch(fetchCtx, run)
+
+	job.Report = run.progress.snapshot()
+	if err != nil {
+		job.State, job.Error = s.fetchJobFailure(job.ID, err)
+		return
+	}
+	job.State = FetchJobStateSucceeded
+}
+
+// fetchWorker takes a slot of the service fetch workers for the download,
+// uploads are read from the caller as they come and take none.
+func (s *service) fetchWorker(ctx context.Context, run *fetchRun) (release func(), err error) {
+	if run.upload != nil {
+		return func() {}, nil
+	}
+
+	release, err = s.pool.acquire(ctx, fetchHost(run.path))
+	if err != nil {
+		return nil, fmt.Errorf("fetchWorker: %w", err)
+	}
+
+	return release, nil
+}
+
+// fetchJobFailure tells the state and the error the job stopped by err finishes with.
+func (s *service) fetchJobFailure(id string, err error) (FetchJobState, string) {
+	switch {
+	case s.jobs.cancelRequested(id):
+		return FetchJobStateCanceled, err.Error()
+	case s.jobs.aborted(id) != nil:
+		return FetchJobStateFailed, fmt.Sprintf("aborted: %v: %v", s.jobs.aborted(id), err)
+	case s.jobs.closed():
+		return FetchJobStateFailed, fmt.Sprintf("interrupted by shutdown: %v", err)
+	default:
+		return FetchJobStateFailed, err.Error()
+	}
+}
+
+func (s *service) startFetchJob(id string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.FetchJobUpdateTimeout)
+	defer cancel()
+
+	started, err := s.storage.StartFetchJob(ctx, id, s.instance)
+	if err != nil {
+		return false, fmt.Errorf("startFetchJob: %w", err)
+	}
+
+	return started, nil
+}
+
+func (s *service) finishFetchJob(job FetchJob) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.FetchJobUpdateTimeout)
+	defer cancel()
+
+	if err := s.storage.FinishFetchJob(ctx, job); err != nil {
+		return fmt.Errorf("finishFetchJob: %w", err)
+	}
+
+	return nil
+}
+
+// heartbeatFetchJob publishes fetch progress, prolongs the fetch locks
+// and picks up cancellation requested through any instance.
+func (s *service) heartbeatFetchJob(ctx context.Context, job FetchJob, progress *fetchProgress) {
+	ticker := time.NewTicker(s.cfg.FetchJobHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		hbCtx, cancel := context.WithTimeout(ctx, s.cfg.FetchJobUpdateTimeout)
+		holder, lock, err := s.acquireFetchLocks(hbCtx, job)
+		cancel()
+		if err != nil {
+			log.Printf("fetch job %s: %v", job.ID, err)
+		}
+		// missed too many heartbeats, someone else writes the feed now
+		if err == nil && holder != job.ID {
+			s.jobs.abort(job.ID, fmt.Errorf("fetch lock %s is taken over by job %s", lock, holder))
+			return
+		}
+
+		hbCtx, cancel = context.WithTimeout(ctx, s.cfg.FetchJobUpdateTimeout)
+		cancelRequested, err := s.storage.HeartbeatFetchJob(hbCtx, job.ID, progress.snapshot())
+		cancel()
+		if err != nil {
+			log.Printf("fetch job %s: %v", job.ID, err)
+			continue
+		}
+
+		if cancelRequested {
+			s.jobs.cancel(job.ID)
+			return
+		}
+	}
+}
+
+// fetchProgress is written by the ingestion pipeline and read by the heartbeat.
+type fetchProgress struct {
+	mu     sync.Mutex
+	report IngestionReport
+}
+
+func (p *fetchProgress) update(fn func(report *IngestionReport)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fn(&p.report)
+}
+
+func (p *fetchProgress) snapshot() IngestionReport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	report := p.report
+	// rows errors themselves are never modified, copying the slice is enough
+	report.Errors = append([]RowError(nil), p.report.Errors...)
+
+	return report
+}
+
+// heartbeats a running job may miss before it is considered gone
+const fetchJobStaleHeartbeats = 3
+
+// runReaper fails jobs left unfinished by instances gone without recording their state,
+// so listings filtered by state tell them apart from the running ones.
+// Every instance reaps, the update is the same whoever runs it.
+func (s *service) runReaper(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.FetchJobHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		if err := s.reapFetchJobs(ctx); err != nil {
+			log.Printf("reaper: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *service) reapFetchJobs(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.FetchJobUpdateTimeout)
+	defer cancel()
+
+	seenBefore := time.Now().Add(-fetchJobStaleHeartbeats * s.cfg.FetchJobHeartbeat)
+	failed, err := s.storage.FailStaleFetchJobs(ctx, seenBefore)
+	if err != nil {
+		return fmt.Errorf("reapFetchJobs: %w", err)
+	}
+	if failed > 0 {
+		log.Printf("reaper: %d abandoned fetch job(s) failed", failed)
+	}
+
+	return nil
+}
+
+// fetchJobAlive tells whether the job is unfinished and the instance running it is not gone.
+func (s *service) fetchJobAlive(job FetchJob, now time.Time) bool {
+	if job.State != FetchJobStatePending && job.State != FetchJobStateRunning {
+		return false
+	}
+
+	seen := job.HeartbeatAt
+	if seen.IsZero() {
+		seen = job.CreatedAt
+	}
+
+	return now.Sub(seen) < fetchJobStaleHeartbeats*s.cfg.FetchJobHeartbeat
+}
@@ -0,0 +1,192 @@
+package products
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// decodeJSON streams elements of a products array,
+// the array may be wrapped into an object, e.g. {"products": [...]}.
+// FeedRow.Line is the element number, not the line of the feed.
+func decodeJSON(body io.Reader, schema FeedSchema, fn func(row FeedRow) error) error {
+	dec := json.NewDecoder(body)
+
+	if err := seekJSONArray(dec); err != nil {
+		return fmt.Errorf("decodeJSON: %w", err)
+	}
+
+	var n uint32
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("decodeJSON: element %d: %w", n+1, err)
+		}
+		n++
+
+		if err := fn(jsonRecordToRow(raw, schema, n)); err != nil {
+			return fmt.Errorf("decodeJSON: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// decodeNDJSON streams one product object per line, malformed lines are rejected on their own.
+func decodeNDJSON(body io.Reader, schema FeedSchema, fn func(row FeedRow) error) error {
+	s := bufio.NewScanner(body)
+	s.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+
+	var line uint32
+	for s.Scan() {
+		line++
+
+		raw := bytes.TrimSpace(s.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		if err := fn(jsonRecordToRow(append([]byte(nil), raw...), schema, line)); err != nil {
+			return fmt.Errorf("decodeNDJSON: %w", err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return fmt.Errorf("decodeNDJSON: line %d: %w", line+1, err)
+	}
+
+	return nil
+}
+
+// seekJSONArray positions decoder inside the products array, that is
+// either the top level array or the first array value of the top level object.
+func seekJSONArray(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("seekJSONArray: %w", err)
+	}
+
+	switch tok {
+	case json.Delim('['):
+		return nil
+	case json.Delim('{'):
+	default:
+		return fmt.Errorf("seekJSONArray: expected array or object, got: %v", tok)
+	}
+
+	for dec.More() {
+		// key
+		if _, err := dec.Token(); err != nil {
+			return fmt.Errorf("seekJSONArray: %w", err)
+		}
+
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("seekJSONArray: %w", err)
+		}
+
+		switch tok {
+		case json.Delim('['):
+			return nil
+		case json.Delim('{'):
+			if err := skipJSONValue(dec); err != nil {
+				return fmt.Errorf("seekJSONArray: %w", err)
+			}
+		}
+	}
+
+	return fmt.Errorf("seekJSONArray: no products array found")
+}
+
+// skipJSONValue skips the rest of the object or array which opening delimiter is already read.
+func skipJSONValue(dec *json.Decoder) error {
+	for depth := 1; depth > 0; {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("skipJSONValue: %w", err)
+		}
+
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+
+	return nil
+}
+
+func jsonRecordToRow(raw json.RawMessage, schema FeedSchema, line uint32) FeedRow {
+	row := FeedRow{
+		Line:   line,
+		Record: []string{string(raw)},
+	}
+	row.Product, row.Err = jsonRecordToProduct(raw, schema)
+
+	return row
+}
+
+func jsonRecordToProduct(raw json.RawMessage, schema FeedSchema) (Product, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return Product{}, fmt.Errorf("jsonRecordToProduct: %w", err)
+	}
+	if tok != json.Delim('{') {
+		return Product{}, fmt.Errorf("jsonRecordToProduct: object expected, got: %v", tok)
+	}
+
+	values := make(map[string]string, len(productFields))
+	prices := schema.Price
+	// keys are read in the object order, the first of keys mapped to the same field wins as with csv columns
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return Product{}, fmt.Errorf("jsonRecordToProduct: %w", err)
+		}
+		key, _ := tok.(string)
+
+		val, err := dec.Token()
+		if err != nil {
+			return Product{}, fmt.Errorf("jsonRecordToProduct: %s: %w", key, err)
+		}
+		// nested object or array, told by its opening delimiter if mapped to a field
+		if _, ok := val.(json.Delim); ok {
+			if err := skipJSONValue(dec); err != nil {
+				return Product{}, fmt.Errorf("jsonRecordToProduct: %s: %w", key, err)
+			}
+		}
+
+		field, ok := fieldByColumnName(schema, key)
+		if !ok {
+			continue
+		}
+		if _, ok := values[field]; ok {
+			continue
+		}
+
+		switch v := val.(type) {
+		case string:
+			values[field] = v
+		case json.Number:
+			values[field] = v.String()
+			// json numbers are never localized
+			if field == fieldPrice {
+				prices = PriceFormat{DecimalSeparator: '.'}
+			}
+		default:
+			return Product{}, fmt.Errorf("jsonRecordToProduct: %s: string or number expected, got: %v", key, val)
+		}
+	}
+
+	p, err := newFeedProduct(values, prices)
+	if err != nil {
+		return p, fmt.Errorf("jsonRecordToProduct: %w", err)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,109 @@
+package products
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		decode func(body io.Reader, schema FeedSchema, fn func(row FeedRow) error) error
+		schema FeedSchema
+		feed   string
+		// see rowString
+		rows    []string
+		wantErr bool
+	}{
+		{
+			name:   "array",
+			decode: decodeJSON,
+			feed:   `[{"name": "a", "price": 1.5}, {"name": "b", "price": "2.50"}]`,
+			rows:   []string{"1 a 1.5", "2 b 2.5"},
+		},
+		{
+			name:   "wrapped array",
+			decode: decodeJSON,
+			feed:   `{"meta": {"pages": [1, 2]}, "total": 1, "products": [{"name": "a", "price": 1}]}`,
+			rows:   []string{"1 a 1"},
+		},
+		{
+			name:   "first of keys mapped to the same field wins",
+			decode: decodeJSON,
+			feed:   `[{"title": "t", "name": "n", "price": 2, "cost": 1}, {"name": "n", "title": "t", "cost": 1, "price": 2}]`,
+			rows:   []string{"1 t 2", "2 n 1"},
+		},
+		{
+			name:   "mapped keys only",
+			decode: decodeJSON,
+			schema: FeedSchema{Columns: map[string]string{"Title": fieldName, "amount": fieldPrice}},
+			feed:   `[{"name": "n", "title": "t", "price": 2, "Amount": 3}]`,
+			rows:   []string{"1 t 3"},
+		},
+		{
+			name:   "nested value of unmapped key",
+			decode: decodeJSON,
+			feed:   `[{"name": "a", "images": ["x", {"y": [1]}], "price": 1}]`,
+			rows:   []string{"1 a 1"},
+		},
+		{
+			name:   "nested value of mapped key",
+			decode: decodeJSON,
+			feed:   `[{"name": {"ru": "a"}, "price": 1}, {"name": "b", "price": 2}]`,
+			rows:   []string{"1 error", "2 b 2"},
+		},
+		{
+			name:   "number price is not localized",
+			decode: decodeJSON,
+			schema: FeedSchema{Price: PriceFormat{DecimalSeparator: ','}},
+			feed:   `[{"name": "a", "price": 1.5}, {"name": "b", "price": "1,5"}]`,
+			rows:   []string{"1 a 1.5", "2 b 1.5"},
+		},
+		{
+			name:    "no array",
+			decode:  decodeJSON,
+			feed:    `{"name": "a", "price": 1}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed element",
+			decode:  decodeJSON,
+			feed:    `[{"name": "a", "price": 1}, {"name": ]`,
+			rows:    []string{"1 a 1"},
+			wantErr: true,
+		},
+		{
+			name:   "lines",
+			decode: decodeNDJSON,
+			feed:   "{\"name\": \"a\", \"price\": 1}\n\nnot json\n[1]\n{\"name\": \"b\", \"price\": 2}\n",
+			rows:   []string{"1 a 1", "3 error", "4 error", "5 b 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []string
+			err := tt.decode(strings.NewReader(tt.feed), tt.schema, func(row FeedRow) error {
+				rows = append(rows, rowString(row))
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("rows = %q, want %q", rows, tt.rows)
+			}
+		})
+	}
+}
+
+// rowString tells line, name and price of the row, or line and error.
+func rowString(row FeedRow) string {
+	if row.Err != nil {
+		return fmt.Sprintf("%d error", row.Line)
+	}
+	return fmt.Sprintf("%d %s %s", row.Line, row.Product.Name, row.Product.Price)
+}
@@ -0,0 +1,157 @@
+package products
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+)
+
+// watchFetchJob returns a channel closed once the job, possibly running on another instance, is finished or gone.
+// The channel is never closed if ctx is done first.
+func (s *service) watchFetchJob(ctx context.Context, id string) <-chan struct{} {
+	done := make(chan struct{})
+
+	go func() {
+		ticker := time.NewTicker(s.cfg.FetchJobHeartbeat)
+		defer ticker.Stop()
+
+		for {
+			job, err := s.storage.FindFetchJob(ctx, id)
+			var notFound errors.ErrNotFound
+			if goErrors.As(err, &notFound) || err == nil && !s.fetchJobAlive(job, time.Now()) {
+				close(done)
+				return
+			}
+			if err != nil && ctx.Err() == nil {
+				log.Printf("fetch job %s: %v", id, err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return done
+}
+
+// feedKey tells feeds fetched by source name from the ones fetched by url.
+func feedKey(source, url string) string {
+	if source != "" {
+		return "source:" + source
+	}
+	return "url:" + url
+}
+
+// fetchLockNames are the leases held by the job writing the feed, so the same feed is never written concurrently:
+// the one of the url, whatever source it is fetched by, and the one of the source, whatever url it has now.
+// Every job takes them in the same order.
+func fetchLockNames(job FetchJob) []string {
+	names := []string{"fetch:" + feedKey("", job.URL)}
+	if job.Source != "" {
+		names = append(names, "fetch:"+feedKey(job.Source, ""))
+	}
+	return names
+}
+
+// acquireFetchLocks takes or prolongs every fetch lock of the job, stopping at the first one held by another job.
+// The holder is returned along with the lock name, the job itself if it holds them all.
+func (s *service) acquireFetchLocks(ctx context.Context, job FetchJob) (holder, lock string, err error) {
+	for _, lock := range fetchLockNames(job) {
+		holder, err := s.storage.AcquireLease(ctx, lock, job.ID, s.fetchLockTTL())
+		if err != nil {
+			return "", lock, fmt.Errorf("acquireFetchLocks: %w", err)
+		}
+		if holder != job.ID {
+			return holder, lock, nil
+		}
+	}
+
+	return job.ID, "", nil
+}
+
+// fetchLockTTL lets the lock of the job gone with its instance expire as soon as the job is considered gone.
+func (s *service) fetchLockTTL() time.Duration {
+	return fetchJobStaleHeartbeats * s.cfg.FetchJobHeartbeat
+}
+
+// lockFetch creates the fetch job holding the fetch locks of its url and source.
+// If the lock is held by the job in flight, the conflict policy is applied,
+// started is false if the job in flight is returned.
+func (s *service) lockFetch(ctx context.Context, job FetchJob, conflict FetchConflict) (FetchJob, bool, error) {
+	var waited string
+	for {
+		created, err := s.storage.CreateFetchJob(ctx, job)
+		if err != nil {
+			return FetchJob{}, false, fmt.Errorf("lockFetch: %w", err)
+		}
+
+		holder, lock, lockErr := s.acquireFetchLocks(ctx, created)
+		if lockErr == nil && holder == created.ID {
+			return created, true, nil
+		}
+
+		// the job has never run, nothing to keep
+		if err := s.releaseFetchLocks(created); err != nil {
+			log.Printf("fetch job %s: %v", created.ID, err)
+		}
+		if err := s.storage.DeleteFetchJob(ctx, created.ID); err != nil {
+			log.Printf("fetch job %s: %v", created.ID, err)
+		}
+		if lockErr != nil {
+			return FetchJob{}, false, fmt.Errorf("lockFetch: %w", lockErr)
+		}
+
+		inFlight, err := s.storage.FindFetchJob(ctx, holder)
+		if err != nil {
+			return FetchJob{}, false, fmt.Errorf("lockFetch: %w", err)
+		}
+
+		switch conflict {
+		case ConflictJoin:
+			return inFlight, false, nil
+		case ConflictReject:
+			return inFlight, false, errors.NewErrAlreadyExists(fmt.Errorf("lockFetch: %s is being fetched by job %s", lock, holder))
+		}
+
+		if holder == waited {
+			// the job is gone with its instance, but its lock has not expired yet
+			pause := time.NewTimer(s.cfg.FetchJobHeartbeat)
+			select {
+			case <-pause.C:
+			case <-ctx.Done():
+				pause.Stop()
+				return inFlight, false, fmt.Errorf("lockFetch: waiting for job %s: %w", holder, ctx.Err())
+			}
+			continue
+		}
+		waited = holder
+
+		select {
+		case <-s.watchFetchJob(ctx, holder):
+		case <-ctx.Done():
+			return inFlight, false, fmt.Errorf("lockFetch: waiting for job %s: %w", holder, ctx.Err())
+		}
+	}
+}
+
+func (s *service) releaseFetchLocks(job FetchJob) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.FetchJobUpdateTimeout)
+	defer cancel()
+
+	// locks taken by others are left as they are
+	var firstErr error
+	for _, lock := range fetchLockNames(job) {
+		if err := s.storage.ReleaseLease(ctx, lock, job.ID); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("releaseFetchLocks: %w", err)
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,209 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+	"google.golang.org/grpc/codes"
+)
+
+func TestFetchLockNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		job   FetchJob
+		locks []string
+	}{
+		{name: "url", job: FetchJob{URL: "https://feeds.example.com/a.csv"}, locks: []string{"fetch:url:https://feeds.example.com/a.csv"}},
+		{name: "source", job: FetchJob{URL: "https://feeds.example.com/a.csv", Source: "shop"}, locks: []string{"fetch:url:https://feeds.example.com/a.csv", "fetch:source:shop"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if locks := fetchLockNames(tt.job); fmt.Sprint(locks) != fmt.Sprint(tt.locks) {
+				t.Errorf("fetchLockNames() = %v, want %v", locks, tt.locks)
+			}
+		})
+	}
+}
+
+func TestLockFetch(t *testing.T) {
+	const (
+		heldURL  = "https://feeds.example.com/a.csv"
+		otherURL = "https://feeds.example.com/b.csv"
+	)
+
+	tests := []struct {
+		name     string
+		job      FetchJob
+		conflict FetchConflict
+		// the job in flight finishes after a while
+		finish  bool
+		started bool
+		code    codes.Code
+	}{
+		{name: "free", job: FetchJob{URL: otherURL}, conflict: ConflictReject, started: true, code: codes.OK},
+		{name: "join same url", job: FetchJob{URL: heldURL}, conflict: ConflictJoin, code: codes.OK},
+		{name: "join same url of another source", job: FetchJob{URL: heldURL, Source: "other"}, conflict: ConflictJoin, code: codes.OK},
+		{name: "join same source with another url", job: FetchJob{URL: otherURL, Source: "shop"}, conflict: ConflictJoin, code: codes.OK},
+		{name: "reject", job: FetchJob{URL: heldURL}, conflict: ConflictReject, code: codes.AlreadyExists},
+		{name: "wait", job: FetchJob{URL: otherURL, Source: "shop"}, conflict: ConflictWait, finish: true, started: true, code: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := newLeaseStorage()
+			held, err := st.CreateFetchJob(context.Background(), FetchJob{URL: heldURL, Source: "shop"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, lock := range fetchLockNames(held) {
+				st.AcquireLease(context.Background(), lock, held.ID, time.Minute)
+			}
+
+			s := &service{
+				storage: st,
+				cfg:     ServiceConfig{FetchJobHeartbeat: 10 * time.Millisecond, FetchJobUpdateTimeout: time.Second},
+			}
+			if tt.finish {
+				go func() {
+					time.Sleep(30 * time.Millisecond)
+					st.finish(held.ID)
+				}()
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+
+			job, started, err := s.lockFetch(ctx, tt.job, tt.conflict)
+			if code := egressCode(err); code != tt.code {
+				t.Fatalf("lockFetch() error = %v, want %s", err, tt.code)
+			}
+			if started != tt.started {
+				t.Fatalf("lockFetch() started = %v, want %v", started, tt.started)
+			}
+			if started {
+				for _, lock := range fetchLockNames(job) {
+					if holder := st.holder(lock); holder != job.ID {
+						t.Errorf("%s is held by %q, want %s", lock, holder, job.ID)
+					}
+				}
+				return
+			}
+
+			if err == nil && job.ID != held.ID {
+				t.Errorf("lockFetch() = job %s, want the one in flight %s", job.ID, held.ID)
+			}
+			// the job created for nothing is gone along with its locks
+			if jobs := st.count(); jobs != 1 {
+				t.Errorf("%d jobs stored, want the one in flight only", jobs)
+			}
+			for _, lock := range fetchLockNames(tt.job) {
+				if holder := st.holder(lock); holder != "" && holder != held.ID {
+					t.Errorf("%s is held by %s", lock, holder)
+				}
+			}
+		})
+	}
+}
+
+// leaseStorage keeps fetch jobs and leases in memory, leases never expire.
+type leaseStorage struct {
+	Storage
+
+	mu      sync.Mutex
+	jobs    map[string]FetchJob
+	leases  map[string]string
+	created int
+}
+
+func newLeaseStorage() *leaseStorage {
+	return &leaseStorage{
+		jobs:   make(map[string]FetchJob),
+		leases: make(map[string]string),
+	}
+}
+
+func (m *leaseStorage) CreateFetchJob(ctx context.Context, job FetchJob) (FetchJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.created++
+	job.ID = fmt.Sprintf("job%d", m.created)
+	job.State = FetchJobStatePending
+	job.CreatedAt = time.Now()
+	m.jobs[job.ID] = job
+	return job, nil
+}
+
+func (m *leaseStorage) DeleteFetchJob(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.jobs, id)
+	return nil
+}
+
+func (m *leaseStorage) FindFetchJob(ctx context.Context, id string) (FetchJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	if !ok {
+		return FetchJob{}, errors.NewErrNotFound(fmt.Errorf("FindFetchJob: job %s not found", id))
+	}
+	return job, nil
+}
+
+func (m *leaseStorage) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if current, ok := m.leases[name]; ok && current != holder {
+		return current, nil
+	}
+	m.leases[name] = holder
+	return holder, nil
+}
+
+func (m *leaseStorage) ReleaseLease(ctx context.Context, name, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.leases[name] == holder {
+		delete(m.leases, name)
+	}
+	return nil
+}
+
+// finish ends the job the way the job runner does, releasing its locks.
+func (m *leaseStorage) finish(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job := m.jobs[id]
+	job.State = FetchJobStateSucceeded
+	m.jobs[id] = job
+	for _, lock := range fetchLockNames(job) {
+		if m.leases[lock] == id {
+			delete(m.leases, lock)
+		}
+	}
+}
+
+func (m *leaseStorage) holder(lock string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.leases[lock]
+}
+
+func (m *leaseStorage) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.jobs)
+}
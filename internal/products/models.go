@@ -14,6 +14,14 @@ type Product struct {
 	Price            decimal.Decimal
 	PriceUpdateCount uint32
 	LastModified     time.Time
+	// supplier's product id, e.g. YML offer id
+	ExternalID string
+	Currency   string
+	// source name or feed url the product was last listed by
+	Source string
+	// archived products are missing from their feed, see SyncPolicy
+	Archived   bool
+	ArchivedAt time.Time
 }
 
 type Paging struct {
@@ -41,3 +49,271 @@ func (s Sorting) Validate() error {
 	}
 	return fmt.Errorf("Validate: can not sort products by field: %s", s.SortBy)
 }
+
+type FetchJobState string
+
+const (
+	FetchJobStatePending   FetchJobState = "pending"
+	FetchJobStateRunning   FetchJobState = "running"
+	FetchJobStateSucceeded FetchJobState = "succeeded"
+	FetchJobStateFailed    FetchJobState = "failed"
+	FetchJobStateCanceled  FetchJobState = "canceled"
+)
+
+type RowError struct {
+	Line   uint32
+	Record []string
+	Reason string
+	// archive entry the row comes from, empty for plain feeds
+	Entry string
+}
+
+type IngestionReport struct {
+	Parsed    uint32
+	Written   uint32
+	Inserted  uint32
+	Repriced  uint32
+	Unchanged uint32
+	Rejected  uint32
+	// capped, see ServiceConfig.MaxReportErrors
+	Errors []RowError
+	// archived by full sync as missing from the feed
+	Archived uint32
+	// archived before, listed by the feed again
+	Restored uint32
+	// why the feed was not ingested at all, empty if it was
+	Skipped string
+	// feed requests repeated after transient failures
+	Retries uint32
+	// state of the feed host circuit breaker after the download
+	Breaker BreakerState
+}
+
+func (r *IngestionReport) reject(e RowError, maxErrors int) {
+	r.Rejected++
+	if len(r.Errors) < maxErrors {
+		r.Errors = append(r.Errors, e)
+	}
+}
+
+func (r *IngestionReport) addUpdateResult(res UpdateResult) {
+	r.Inserted += res.Inserted
+	r.Repriced += res.Repriced
+	r.Unchanged += res.Unchanged
+	r.Restored += res.Restored
+	r.Written += res.Inserted + res.Repriced
+}
+
+// FeedRow is a product parsed from the feed along with its position and raw record.
+// Rows failed to parse carry Err, along with the product name if the row got as far as it.
+type FeedRow struct {
+	Line    uint32
+	Record  []string
+	Product Product
+	Err     error
+	// archive entry the row comes from, lines are counted per entry
+	Entry string
+}
+
+type ErrorPolicy struct {
+	// abort on the first rejected row if false
+	Skip bool
+	// with Skip, aborts once more rows rejected, unlimited if zero
+	MaxRejected uint32
+	// with Skip, fails once the whole feed is read and more percent of rows rejected, unlimited if zero
+	MaxRejectedPercent float64
+}
+
+func (p ErrorPolicy) Validate() error {
+	if p.MaxRejectedPercent < 0 || p.MaxRejectedPercent > 100 {
+		return fmt.Errorf("Validate: max rejected percent must be within [0, 100], got: %v", p.MaxRejectedPercent)
+	}
+	if !p.Skip && (p.MaxRejected > 0 || p.MaxRejectedPercent > 0) {
+		return fmt.Errorf("Validate: rejected rows limits make sense only when skipping rejected rows")
+	}
+	return nil
+}
+
+// check tells whether the fetch may go on, percent limit is checked only once the feed is complete.
+func (p ErrorPolicy) check(r IngestionReport, complete bool) error {
+	if r.Rejected == 0 {
+		return nil
+	}
+
+	if !p.Skip {
+		return fmt.Errorf("check: %d row(s) rejected, aborting", r.Rejected)
+	}
+
+	if p.MaxRejected > 0 && r.Rejected > p.MaxRejected {
+		return fmt.Errorf("check: %d rows rejected, more than allowed %d", r.Rejected, p.MaxRejected)
+	}
+
+	if complete && p.MaxRejectedPercent > 0 {
+		percent := float64(r.Rejected) / float64(r.Parsed) * 100
+		if percent > p.MaxRejectedPercent {
+			return fmt.Errorf("check: %.2f%% rows rejected, more than allowed %.2f%%", percent, p.MaxRejectedPercent)
+		}
+	}
+
+	return nil
+}
+
+// UpdateResult reports what happened to every product passed to Storage.UpdateProducts.
+type UpdateResult struct {
+	Inserted  uint32
+	Repriced  uint32
+	Unchanged uint32
+	// archived products listed again
+	Restored uint32
+	// indexes of products refused by the storage along with the reasons
+	Rejected map[int]string
+}
+
+// duplicateNameReason rejects products repeating the name of one written along in the same batch.
+func duplicateNameReason(name string) string {
+	return fmt.Sprintf("product %q is listed again within the same batch of rows", name)
+}
+
+func (r *UpdateResult) reject(i int, reason string) {
+	if r.Rejected == nil {
+		r.Rejected = make(map[int]string)
+	}
+	r.Rejected[i] = reason
+}
+
+type FetchJob struct {
+	ID  string
+	URL string
+	// empty if fetched by url
+	Source          string
+	State           FetchJobState
+	Instance        string
+	CancelRequested bool
+	CreatedAt       time.Time
+	StartedAt       time.Time
+	FinishedAt      time.Time
+	HeartbeatAt     time.Time
+	Report          IngestionReport
+	Error           string
+}
+
+type FetchJobsFilter struct {
+	Limit  uint32
+	LastID string
+	States []FetchJobState
+}
+
+// PriceOrigin tells what caused price changes.
+type PriceOrigin struct {
+	// source name or feed url the prices come from
+	Source string
+	JobID  string
+}
+
+type PriceEvent string
+
+const (
+	PriceEventPrice    PriceEvent = "price"
+	PriceEventArchived PriceEvent = "archived"
+	PriceEventRestored PriceEvent = "restored"
+)
+
+// PriceChange is a price set for the product, the first one has no OldPrice.
+// Archiving and restoring the product are recorded along with its price at the moment.
+type PriceChange struct {
+	ID        string
+	Event     PriceEvent
+	ProductID string
+	Name      string
+	OldPrice  *decimal.Decimal
+	NewPrice  decimal.Decimal
+	Currency  string
+	ChangedAt time.Time
+	Source    string
+	JobID     string
+}
+
+// PriceHistoryFilter selects price changes in chronological order,
+// From is inclusive, To is exclusive, zero values are not applied.
+type PriceHistoryFilter struct {
+	ProductID string
+	Name      string
+	From      time.Time
+	To        time.Time
+	Limit     uint32
+	LastID    string
+}
+
+// PriceDelta is a price change Fetch would make, Product holds the new price.
+type PriceDelta struct {
+	Product  Product
+	OldPrice decimal.Decimal
+	Delta    decimal.Decimal
+}
+
+// FetchDiff is what Fetch would change, item lists are capped by ServiceConfig.MaxDiffItems.
+// Report counts rows as if they were written, storage may still refuse some of them.
+type FetchDiff struct {
+	Report    IngestionReport
+	Increased uint32
+	Decreased uint32
+	New       []Product
+	Increases []PriceDelta
+	Decreases []PriceDelta
+}
+
+type SyncMode int
+
+const (
+	// products missing from the feed are kept as is
+	SyncUpsert SyncMode = iota
+	// products missing from the feed are archived
+	SyncFull
+)
+
+type SyncPolicy struct {
+	Mode SyncMode
+	// consecutive full sync fetches the product must be missing from to get archived, 1 if zero
+	MissedFetches uint32
+}
+
+func (p SyncPolicy) Validate() error {
+	if p.Mode != SyncUpsert && p.Mode != SyncFull {
+		return fmt.Errorf("Validate: unknown sync mode: %d", p.Mode)
+	}
+	return nil
+}
+
+func (p SyncPolicy) missedFetches() uint32 {
+	if p.MissedFetches == 0 {
+		return 1
+	}
+	return p.MissedFetches
+}
+
+// FetchConflict tells Fetch what to do if the same source or url is being fetched already.
+type FetchConflict int
+
+const (
+	// return the job in flight instead of starting a new one
+	ConflictJoin FetchConflict = iota
+	// wait for the job in flight to finish and start a new one then
+	ConflictWait
+	// fail with ErrAlreadyExists
+	ConflictReject
+)
+
+func (c FetchConflict) Validate() error {
+	if c != ConflictJoin && c != ConflictWait && c != ConflictReject {
+		return fmt.Errorf("Validate: unknown fetch conflict policy: %d", c)
+	}
+	return nil
+}
+
+// FeedVersion tells whether the feed changed since the last fetch.
+type FeedVersion struct {
+	ETag         string
+	LastModified string
+	// hex encoded SHA-256 of the body as downloaded
+	SHA256 string
+}
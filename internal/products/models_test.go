@@ -0,0 +1,56 @@
+package products
+
+import "testing"
+
+func TestErrorPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  ErrorPolicy
+		wantErr bool
+	}{
+		{name: "abort"},
+		{name: "skip", policy: ErrorPolicy{Skip: true}},
+		{name: "skip with limits", policy: ErrorPolicy{Skip: true, MaxRejected: 10, MaxRejectedPercent: 5}},
+		{name: "limits without skip", policy: ErrorPolicy{MaxRejected: 10}, wantErr: true},
+		{name: "percent without skip", policy: ErrorPolicy{MaxRejectedPercent: 5}, wantErr: true},
+		{name: "negative percent", policy: ErrorPolicy{Skip: true, MaxRejectedPercent: -1}, wantErr: true},
+		{name: "percent over 100", policy: ErrorPolicy{Skip: true, MaxRejectedPercent: 101}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestErrorPolicyCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   ErrorPolicy
+		parsed   uint32
+		rejected uint32
+		complete bool
+		wantErr  bool
+	}{
+		{name: "nothing rejected", parsed: 10},
+		{name: "abort on rejected", parsed: 10, rejected: 1, wantErr: true},
+		{name: "skip", policy: ErrorPolicy{Skip: true}, parsed: 10, rejected: 9, complete: true},
+		{name: "max rejected reached", policy: ErrorPolicy{Skip: true, MaxRejected: 2}, parsed: 10, rejected: 2},
+		{name: "max rejected exceeded", policy: ErrorPolicy{Skip: true, MaxRejected: 2}, parsed: 10, rejected: 3, wantErr: true},
+		{name: "percent not checked before complete", policy: ErrorPolicy{Skip: true, MaxRejectedPercent: 10}, parsed: 10, rejected: 5},
+		{name: "percent reached", policy: ErrorPolicy{Skip: true, MaxRejectedPercent: 50}, parsed: 10, rejected: 5, complete: true},
+		{name: "percent exceeded", policy: ErrorPolicy{Skip: true, MaxRejectedPercent: 10}, parsed: 10, rejected: 2, complete: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.check(IngestionReport{Parsed: tt.parsed, Rejected: tt.rejected}, tt.complete)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
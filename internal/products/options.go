@@ -1,10 +1,28 @@
 package products
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type optsHolder struct {
-	paging  *Paging
-	sorting *Sorting
+	paging      *Paging
+	sorting     *Sorting
+	wait        bool
+	errorPolicy ErrorPolicy
+	schema      FeedSchema
+	format      FeedFormat
+	archive     ArchiveOptions
+	asOf        *time.Time
+	sync        SyncPolicy
+	archived    bool
+	source      string
+	headers     map[string]string
+	auth        SourceAuth
+	conflict    FetchConflict
+	force       bool
+	version     FeedVersion
+	upload      FeedUpload
 }
 
 type option func(opts *optsHolder)
@@ -30,6 +48,111 @@ func (so optsMethods) WithSorting(s Sorting) (option, error) {
 	}, nil
 }
 
+// WithAsOf makes List return products as they were at the moment, rebuilt from the price history.
+func (so optsMethods) WithAsOf(t time.Time) option {
+	return func(opts *optsHolder) {
+		opts.asOf = &t
+	}
+}
+
+// WithIncludeArchived makes List return archived products as well.
+func (so optsMethods) WithIncludeArchived(include bool) option {
+	return func(opts *optsHolder) {
+		opts.archived = include
+	}
+}
+
+func (so optsMethods) WithSyncPolicy(p SyncPolicy) (option, error) {
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("WithSyncPolicy: %s", err)
+	}
+	return func(opts *optsHolder) {
+		opts.sync = p
+	}, nil
+}
+
+// WithFetchConflict tells Fetch what to do if the source or url is being fetched already.
+func (so optsMethods) WithFetchConflict(c FetchConflict) (option, error) {
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("WithFetchConflict: %s", err)
+	}
+	return func(opts *optsHolder) {
+		opts.conflict = c
+	}, nil
+}
+
+// WithForce makes Fetch ingest the feed even if it is the same as the last time.
+func (so optsMethods) WithForce(force bool) option {
+	return func(opts *optsHolder) {
+		opts.force = force
+	}
+}
+
+// withFeedVersion passes the version of the last fetch to the client.
+func (so optsMethods) withFeedVersion(v FeedVersion) option {
+	return func(opts *optsHolder) {
+		opts.version = v
+	}
+}
+
+// withUpload makes the fetch job read the feed pushed by the caller instead of downloading it.
+func (so optsMethods) withUpload(u FeedUpload) option {
+	return func(opts *optsHolder) {
+		opts.upload = u
+	}
+}
+
+// WithSource makes Fetch take url and feed options from the stored source,
+// options passed along override the stored ones.
+func (so optsMethods) WithSource(name string) option {
+	return func(opts *optsHolder) {
+		opts.source = name
+	}
+}
+
+// WithWait makes Fetch block until the fetch job finishes.
+func (so optsMethods) WithWait(wait bool) option {
+	return func(opts *optsHolder) {
+		opts.wait = wait
+	}
+}
+
+func (so optsMethods) WithErrorPolicy(p ErrorPolicy) (option, error) {
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("WithErrorPolicy: %s", err)
+	}
+	return func(opts *optsHolder) {
+		opts.errorPolicy = p
+	}, nil
+}
+
+func (so optsMethods) WithFeedSchema(s FeedSchema) (option, error) {
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("WithFeedSchema: %s", err)
+	}
+	return func(opts *optsHolder) {
+		opts.schema = s
+	}, nil
+}
+
+func (so optsMethods) WithFeedFormat(f FeedFormat) (option, error) {
+	if err := f.Validate(); err != nil {
+		return nil, fmt.Errorf("WithFeedFormat: %s", err)
+	}
+	return func(opts *optsHolder) {
+		opts.format = f
+	}, nil
+}
+
+func (so optsMethods) WithArchive(a ArchiveOptions) (option, error) {
+	if err := a.Validate(); err != nil {
+		return nil, fmt.Errorf("WithArchive: %s", err)
+	}
+	return func(opts *optsHolder) {
+		opts.archive = a
+	}, nil
+}
+
 func applyOptions(opts []option) *optsHolder {
 	var hder optsHolder
 	for _, o := range opts {
@@ -0,0 +1,232 @@
+package products
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+)
+
+// PriceFormat describes how prices are written in the feed, zero value means detect per value.
+type PriceFormat struct {
+	// '.' or ',', detected if zero, values like 1,299 or 1.299 can't be told apart and are rejected
+	DecimalSeparator rune
+	// spaces and apostrophes are always accepted as grouping separators,
+	// if zero, the one of '.' and ',' not used as decimal separator is accepted as well
+	GroupSeparator rune
+}
+
+func (f PriceFormat) Validate() error {
+	if f.DecimalSeparator != 0 && f.DecimalSeparator != '.' && f.DecimalSeparator != ',' {
+		return fmt.Errorf("Validate: decimal separator must be '.' or ',', got: %q", f.DecimalSeparator)
+	}
+	if f.GroupSeparator != 0 && (unicode.IsDigit(f.GroupSeparator) || f.GroupSeparator == '-' || f.GroupSeparator == '+') {
+		return fmt.Errorf("Validate: invalid grouping separator: %q", f.GroupSeparator)
+	}
+	if f.GroupSeparator != 0 && f.GroupSeparator == f.DecimalSeparator {
+		return fmt.Errorf("Validate: grouping separator is the same as decimal separator: %q", f.GroupSeparator)
+	}
+	return nil
+}
+
+// currencySymbols maps symbols and abbreviations met in feeds to ISO 4217 codes,
+// any three latin letters are taken for the code itself.
+var currencySymbols = map[string]string{
+	"₽":    "RUB",
+	"р":    "RUB",
+	"р.":   "RUB",
+	"руб":  "RUB",
+	"руб.": "RUB",
+	"$":    "USD",
+	"us$":  "USD",
+	"€":    "EUR",
+	"£":    "GBP",
+	"¥":    "JPY",
+	"₴":    "UAH",
+	"грн":  "UAH",
+	"грн.": "UAH",
+	"₸":    "KZT",
+	"₺":    "TRY",
+	"₹":    "INR",
+	"zł":   "PLN",
+}
+
+func isCurrencyRune(r rune) bool {
+	return unicode.Is(unicode.Sc, r) || unicode.IsLetter(r) || r == '.'
+}
+
+// parsePrice parses price exactly, separators are checked to stand where they belong,
+// currency is returned if the price carries a symbol or code.
+func parsePrice(s string, f PriceFormat) (price decimal.Decimal, currency string, err error) {
+	raw := s
+	s = strings.TrimSpace(s)
+
+	// currency goes either before or after the number, dots alone belong to the number
+	var symbol string
+	notCurrency := func(r rune) bool { return !isCurrencyRune(r) }
+	isSymbol := func(sym string) bool { return strings.Trim(sym, " .") != "" }
+	if i := strings.IndexFunc(s, notCurrency); i > 0 && isSymbol(s[:i]) {
+		symbol, s = s[:i], s[i:]
+	} else if i := strings.LastIndexFunc(s, notCurrency); i >= 0 && i < len(s)-1 {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		if isSymbol(s[i+size:]) {
+			symbol, s = s[i+size:], s[:i+size]
+		}
+	}
+	if symbol = strings.Trim(symbol, " ."); symbol != "" {
+		currency, err = currencyBySymbol(symbol)
+		if err != nil {
+			return decimal.Decimal{}, "", fmt.Errorf("parsePrice: %q: %w", raw, err)
+		}
+	}
+	s = strings.TrimSpace(s)
+
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+	if s == "" {
+		return decimal.Decimal{}, "", fmt.Errorf("parsePrice: no digits in price")
+	}
+
+	dec := f.DecimalSeparator
+	if dec == 0 {
+		dec, err = detectDecimalSeparator(s)
+		if err != nil {
+			return decimal.Decimal{}, "", fmt.Errorf("parsePrice: %q: %w", raw, err)
+		}
+	}
+
+	intPart, fracPart := s, ""
+	if dec != 0 {
+		if strings.Count(s, string(dec)) > 1 {
+			return decimal.Decimal{}, "", fmt.Errorf("parsePrice: %q: more than one decimal separator %q", raw, dec)
+		}
+		if i := strings.IndexRune(s, dec); i >= 0 {
+			intPart, fracPart = s[:i], s[i+1:]
+			if fracPart == "" || !isDigits(fracPart) {
+				return decimal.Decimal{}, "", fmt.Errorf("parsePrice: %q: digits expected after decimal separator", raw)
+			}
+		}
+	}
+
+	digits, err := ungroup(intPart, dec, f.GroupSeparator)
+	if err != nil {
+		return decimal.Decimal{}, "", fmt.Errorf("parsePrice: %q: %w", raw, err)
+	}
+	if digits == "" {
+		digits = "0"
+	}
+
+	canonical := sign + digits
+	if fracPart != "" {
+		canonical += "." + fracPart
+	}
+
+	price, err = decimal.NewFromString(canonical)
+	if err != nil {
+		return decimal.Decimal{}, "", fmt.Errorf("parsePrice: %w", err)
+	}
+
+	return price, currency, nil
+}
+
+func currencyBySymbol(symbol string) (string, error) {
+	if code, ok := currencySymbols[strings.ToLower(symbol)]; ok {
+		return code, nil
+	}
+
+	if len(symbol) == 3 && strings.IndexFunc(symbol, func(r rune) bool { return r > unicode.MaxASCII || !unicode.IsLetter(r) }) < 0 {
+		return strings.ToUpper(symbol), nil
+	}
+
+	return "", fmt.Errorf("currencyBySymbol: unknown currency: %s", symbol)
+}
+
+// detectDecimalSeparator takes the last of '.' and ',' if both are present,
+// the one repeated is grouping, the single one is decimal unless followed by exactly three digits.
+func detectDecimalSeparator(s string) (rune, error) {
+	dot, comma := strings.LastIndexByte(s, '.'), strings.LastIndexByte(s, ',')
+
+	switch {
+	case dot < 0 && comma < 0:
+		return 0, nil
+	case dot >= 0 && comma >= 0:
+		if dot > comma {
+			return '.', nil
+		}
+		return ',', nil
+	}
+
+	sep, i := '.', dot
+	if comma >= 0 {
+		sep, i = ',', comma
+	}
+
+	if strings.Count(s, string(sep)) > 1 {
+		return 0, nil
+	}
+
+	intPart, fracPart := s[:i], s[i+1:]
+	if len(fracPart) != 3 {
+		return sep, nil
+	}
+	// grouping can't follow a lone zero, and there is no other grouping separator the same number could use
+	if strings.TrimLeft(intPart, "0") == "" || strings.IndexFunc(intPart, isGroupingSpace) >= 0 {
+		return sep, nil
+	}
+
+	return 0, fmt.Errorf("detectDecimalSeparator: ambiguous %q, decimal separator may be set in schema", sep)
+}
+
+func isGroupingSpace(r rune) bool {
+	return unicode.IsSpace(r) || r == '\'' || r == '’'
+}
+
+// ungroup drops grouping separators checking that digits are grouped by three.
+func ungroup(s string, dec, group rune) (string, error) {
+	isGroup := func(r rune) bool {
+		if isGroupingSpace(r) || (group != 0 && r == group) {
+			return true
+		}
+		return group == 0 && (r == '.' || r == ',') && r != dec
+	}
+
+	var (
+		groups []string
+		start  int
+	)
+	for i, r := range s {
+		if !isGroup(r) {
+			continue
+		}
+		groups = append(groups, s[start:i])
+		start = i + utf8.RuneLen(r)
+	}
+	groups = append(groups, s[start:])
+
+	for i, g := range groups {
+		if !isDigits(g) {
+			return "", fmt.Errorf("ungroup: unexpected characters: %s", g)
+		}
+		if len(groups) == 1 {
+			break
+		}
+		if (i == 0 && (g == "" || len(g) > 3)) || (i > 0 && len(g) != 3) {
+			return "", fmt.Errorf("ungroup: digits must be grouped by three")
+		}
+	}
+
+	return strings.Join(groups, ""), nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package products
+
+import (
+	"testing"
+)
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		in       string
+		format   PriceFormat
+		price    string
+		currency string
+		wantErr  bool
+	}{
+		{in: "12", price: "12"},
+		{in: "12.5", price: "12.5"},
+		{in: "12,5", price: "12.5"},
+		{in: " 0.99 ", price: "0.99"},
+		{in: "-3.10", price: "-3.1"},
+		{in: "+7", price: "7"},
+		{in: ".5", price: "0.5"},
+		{in: "1,299.99", price: "1299.99"},
+		{in: "1.299,99", price: "1299.99"},
+		{in: "1 299,99", price: "1299.99"},
+		{in: "1 299,99", price: "1299.99"},
+		{in: "1'299.99", price: "1299.99"},
+		{in: "1,234,567", price: "1234567"},
+		{in: "1.234.567", price: "1234567"},
+		{in: "0,123", price: "0.123"},
+		{in: "1 299,123", price: "1299.123"},
+		{in: "1,299", wantErr: true},
+		{in: "1.299", wantErr: true},
+		{in: "1,299", format: PriceFormat{DecimalSeparator: ','}, price: "1.299"},
+		{in: "1,299", format: PriceFormat{DecimalSeparator: '.'}, price: "1299"},
+		{in: "1.299", format: PriceFormat{DecimalSeparator: ','}, price: "1299"},
+		{in: "1_299.5", format: PriceFormat{GroupSeparator: '_'}, price: "1299.5"},
+		{in: "1,299.5", format: PriceFormat{GroupSeparator: '_'}, wantErr: true},
+		{in: "$12.50", price: "12.5", currency: "USD"},
+		{in: "12.50 $", price: "12.5", currency: "USD"},
+		{in: "1 299 ₽", price: "1299", currency: "RUB"},
+		{in: "1 299 руб.", price: "1299", currency: "RUB"},
+		{in: "€5", price: "5", currency: "EUR"},
+		{in: "12.50 usd", price: "12.5", currency: "USD"},
+		{in: "EUR 3,5", price: "3.5", currency: "EUR"},
+		{in: "12 credits", wantErr: true},
+		{in: "12,34,567", wantErr: true},
+		{in: "1,2345.5", wantErr: true},
+		{in: "1.2.3,5", wantErr: true},
+		{in: "1,5,5.5", wantErr: true},
+		{in: "12.", wantErr: true},
+		{in: "12.5x", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "$", wantErr: true},
+		{in: "-", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			price, currency, err := parsePrice(tt.in, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePrice(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if price.String() != tt.price {
+				t.Errorf("parsePrice(%q) price = %s, want %s", tt.in, price, tt.price)
+			}
+			if currency != tt.currency {
+				t.Errorf("parsePrice(%q) currency = %q, want %q", tt.in, currency, tt.currency)
+			}
+		})
+	}
+}
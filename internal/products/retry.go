@@ -0,0 +1,134 @@
+package products
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+)
+
+// RetryPolicy retries feed requests failed before the body is read: network errors, 408, 429 and 5xx but 501.
+// Retries stop once any row is streamed, rows would be ingested twice otherwise.
+type RetryPolicy struct {
+	// attempts including the first one, no retries if 1 or less
+	MaxAttempts int
+	// delay before the first retry, doubled for every next one and jittered
+	BaseDelay time.Duration
+	// longest delay, retries stop if the server asks to wait longer with Retry-After
+	MaxDelay time.Duration
+}
+
+// maxDrainedBody is read from the failed response before retrying, so the connection may be reused.
+const maxDrainedBody = 64 << 10
+
+// do sends the request retrying transient failures, the last response or error is returned as is.
+func (c *httpClient) do(ctx context.Context, cli *http.Client, req *http.Request, res *ListResult) (*http.Response, error) {
+	policy := c.cfg.Retry
+
+	for attempt := 1; ; attempt++ {
+		fetchMetrics.Add("requests", 1)
+		resp, err := cli.Do(req.Clone(ctx))
+		if ctx.Err() != nil || !transientFailure(resp, err) || attempt >= policy.MaxAttempts {
+			return resp, err
+		}
+
+		wait := backoff(policy, attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if policy.MaxDelay > 0 && after > policy.MaxDelay {
+					return resp, err
+				}
+				if after > wait {
+					wait = after
+				}
+			}
+			io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxDrainedBody))
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("do: waiting to retry: %w", ctx.Err())
+		case <-timer.C:
+		}
+
+		res.Retries++
+		fetchMetrics.Add("retries", 1)
+	}
+}
+
+// transientFailure tells whether the same request may succeed later.
+func transientFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		var urlErr *url.Error
+		if goErrors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+
+		// refused by the egress policy or the redirect limit
+		var permissionDenied errors.ErrPermissionDenied
+		if goErrors.As(err, &permissionDenied) {
+			return false
+		}
+
+		var netErr net.Error
+		return goErrors.As(err, &netErr) || goErrors.Is(err, io.EOF) || goErrors.Is(err, io.ErrUnexpectedEOF)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return true
+	case http.StatusNotImplemented:
+		return false
+	default:
+		return resp.StatusCode >= 500
+	}
+}
+
+// backoff returns exponential delay before the retry with jitter in its upper half,
+// so instances failed at once do not retry at once.
+func backoff(policy RetryPolicy, attempt int) time.Duration {
+	delay := policy.BaseDelay
+	// doubling stops short of overflow if there is no max delay
+	for i := 1; i < attempt && (policy.MaxDelay <= 0 || delay < policy.MaxDelay) && delay <= math.MaxInt64/2; i++ {
+		delay *= 2
+	}
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// retryAfter parses Retry-After given in seconds or as http date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
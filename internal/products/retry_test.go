@@ -0,0 +1,210 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		delay   time.Duration
+	}{
+		{name: "first", policy: RetryPolicy{BaseDelay: time.Second}, attempt: 1, delay: time.Second},
+		{name: "doubled", policy: RetryPolicy{BaseDelay: time.Second}, attempt: 3, delay: 4 * time.Second},
+		{name: "capped", policy: RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}, attempt: 4, delay: 5 * time.Second},
+		{name: "capped far away", policy: RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}, attempt: 100, delay: 5 * time.Second},
+		{name: "uncapped far away", policy: RetryPolicy{BaseDelay: time.Second}, attempt: 100, delay: time.Second << 33},
+		{name: "no delay", policy: RetryPolicy{}, attempt: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if d := backoff(tt.policy, tt.attempt); d < tt.delay/2 || d > tt.delay {
+					t.Fatalf("backoff() = %s, want within %s-%s", d, tt.delay/2, tt.delay)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2021, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		after time.Duration
+		ok    bool
+	}{
+		{value: ""},
+		{value: "120", after: 2 * time.Minute, ok: true},
+		{value: "0", ok: true},
+		{value: "-1"},
+		{value: "soon"},
+		{value: "Fri, 15 Jan 2021 10:31:30 GMT", after: 90 * time.Second, ok: true},
+		{value: "Fri, 15 Jan 2021 10:00:00 GMT", ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			after, ok := retryAfter(tt.value, now)
+			if after != tt.after || ok != tt.ok {
+				t.Errorf("retryAfter(%q) = %s, %v, want %s, %v", tt.value, after, ok, tt.after, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTransientFailure(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		err       error
+		transient bool
+	}{
+		{name: "ok", status: http.StatusOK},
+		{name: "not found", status: http.StatusNotFound},
+		{name: "request timeout", status: http.StatusRequestTimeout, transient: true},
+		{name: "too many requests", status: http.StatusTooManyRequests, transient: true},
+		{name: "internal error", status: http.StatusInternalServerError, transient: true},
+		{name: "not implemented", status: http.StatusNotImplemented},
+		{name: "unavailable", status: http.StatusServiceUnavailable, transient: true},
+		{name: "eof", err: io.ErrUnexpectedEOF, transient: true},
+		{name: "other error", err: errors.New("boom")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp *http.Response
+			if tt.err == nil {
+				resp = &http.Response{StatusCode: tt.status}
+			}
+			if got := transientFailure(resp, tt.err); got != tt.transient {
+				t.Errorf("transientFailure() = %v, want %v", got, tt.transient)
+			}
+		})
+	}
+}
+
+func TestRetryDo(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     RetryPolicy
+		failures   int32
+		failStatus int
+		retryAfter string
+		status     int
+		requests   int32
+	}{
+		{
+			name:       "recovered",
+			policy:     RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			failures:   2,
+			failStatus: http.StatusServiceUnavailable,
+			status:     http.StatusOK,
+			requests:   3,
+		},
+		{
+			name:       "attempts exhausted",
+			policy:     RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			failures:   5,
+			failStatus: http.StatusBadGateway,
+			status:     http.StatusBadGateway,
+			requests:   3,
+		},
+		{
+			name:       "not retried",
+			policy:     RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond},
+			failures:   5,
+			failStatus: http.StatusNotFound,
+			status:     http.StatusNotFound,
+			requests:   1,
+		},
+		{
+			name:       "retries off",
+			policy:     RetryPolicy{MaxAttempts: 1, BaseDelay: time.Millisecond},
+			failures:   5,
+			failStatus: http.StatusServiceUnavailable,
+			status:     http.StatusServiceUnavailable,
+			requests:   1,
+		},
+		{
+			name:       "retry after too long",
+			policy:     RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second},
+			failures:   5,
+			failStatus: http.StatusTooManyRequests,
+			retryAfter: "3600",
+			status:     http.StatusTooManyRequests,
+			requests:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requests, 1) <= tt.failures {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(tt.failStatus)
+					return
+				}
+				io.WriteString(w, "name;price\n")
+			}))
+			defer srv.Close()
+
+			c := &httpClient{cfg: ClientConfig{Retry: tt.policy}}
+			req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var res ListResult
+			resp, err := c.do(context.Background(), srv.Client(), req, &res)
+			if err != nil {
+				t.Fatalf("do: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if got := atomic.LoadInt32(&requests); got != tt.requests {
+				t.Errorf("requests = %d, want %d", got, tt.requests)
+			}
+			if res.Retries != uint32(tt.requests-1) {
+				t.Errorf("retries = %d, want %d", res.Retries, tt.requests-1)
+			}
+		})
+	}
+}
+
+func TestRetryDoCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := &httpClient{cfg: ClientConfig{Retry: RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour}}}
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := c.do(ctx, srv.Client(), req, &ListResult{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("do() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
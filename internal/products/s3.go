@@ -0,0 +1,175 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+)
+
+// S3Config tells where s3://bucket/key feeds are fetched from, AWS defaults are used for anything omitted.
+type S3Config struct {
+	// e.g. http://minio:9000 for MinIO, AWS if empty; the host is subject to the egress policy
+	Endpoint string
+	// us-east-1 if neither set nor found in the AWS environment
+	Region string
+	// static credentials, AWS credentials chain if empty; sources may override them with basic auth
+	AccessKeyID     string
+	SecretAccessKey string
+	// bucket goes into the path instead of the host name, needed by MinIO
+	PathStyle bool
+}
+
+const defaultS3Region = "us-east-1"
+
+func newS3Session(cfg S3Config, transport http.RoundTripper, retry RetryPolicy) (*session.Session, error) {
+	maxRetries := retry.MaxAttempts - 1
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	awsCfg := aws.Config{
+		HTTPClient:       &http.Client{Transport: transport},
+		S3ForcePathStyle: aws.Bool(cfg.PathStyle),
+		// prefix listing only, objects are downloaded and retried the way http feeds are
+		MaxRetries: aws.Int(maxRetries),
+	}
+	if cfg.Endpoint != "" {
+		awsCfg.Endpoint = aws.String(cfg.Endpoint)
+	}
+	if cfg.Region != "" {
+		awsCfg.Region = aws.String(cfg.Region)
+	}
+	if cfg.AccessKeyID != "" {
+		awsCfg.Credentials = credentials.NewStaticCredentials(cfg.AccessKeyID, cfg.SecretAccessKey, "")
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		Config:            awsCfg,
+		SharedConfigState: session.SharedConfigEnable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("newS3Session: %w", err)
+	}
+	if aws.StringValue(sess.Config.Region) == "" {
+		sess.Config.Region = aws.String(defaultS3Region)
+	}
+
+	return sess, nil
+}
+
+func isS3URL(u *url.URL) bool {
+	return strings.EqualFold(u.Scheme, "s3")
+}
+
+// s3Location splits s3 url into bucket and key, keys ending with / or * are prefixes.
+func s3Location(u *url.URL) (bucket, key string, prefix bool, err error) {
+	bucket = u.Host
+	if bucket == "" {
+		return "", "", false, errors.NewErrInvalidInput(fmt.Errorf("s3Location: bucket is required"))
+	}
+	key = strings.TrimPrefix(u.Path, "/")
+	if key == "" {
+		return "", "", false, errors.NewErrInvalidInput(fmt.Errorf("s3Location: object key or prefix is required"))
+	}
+
+	if strings.HasSuffix(key, "*") {
+		return bucket, strings.TrimSuffix(key, "*"), true, nil
+	}
+	return bucket, key, strings.HasSuffix(key, "/"), nil
+}
+
+// s3Client signs requests with the source credentials if any.
+func (c *httpClient) s3Client(auth SourceAuth) (*s3.S3, error) {
+	if auth.Username == "" {
+		return s3.New(c.s3), nil
+	}
+
+	secret, err := auth.Password.read(c.cfg.SecretsDir)
+	if err != nil {
+		return nil, fmt.Errorf("s3Client: %w", err)
+	}
+
+	return s3.New(c.s3, aws.NewConfig().WithCredentials(credentials.NewStaticCredentials(auth.Username, secret, ""))), nil
+}
+
+// s3Request returns the signed object request along with the feed name telling the object key,
+// the newest object is taken if the url is a prefix.
+func (c *httpClient) s3Request(ctx context.Context, u *url.URL, optsHolder *optsHolder) (*http.Request, string, error) {
+	bucket, key, prefix, err := s3Location(u)
+	if err != nil {
+		return nil, "", fmt.Errorf("s3Request: %w", err)
+	}
+
+	svc, err := c.s3Client(optsHolder.auth)
+	if err != nil {
+		return nil, "", fmt.Errorf("s3Request: %w", err)
+	}
+
+	if prefix {
+		if key, err = newestS3Object(ctx, svc, bucket, key); err != nil {
+			return nil, "", fmt.Errorf("s3Request: %w", err)
+		}
+	}
+
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+	prev := optsHolder.version
+	if prev.ETag != "" {
+		input.IfNoneMatch = aws.String(prev.ETag)
+	}
+	if prev.LastModified != "" {
+		if t, err := http.ParseTime(prev.LastModified); err == nil {
+			input.IfModifiedSince = aws.Time(t)
+		}
+	}
+
+	awsReq, _ := svc.GetObjectRequest(input)
+	awsReq.SetContext(ctx)
+	// set before signing, so the transport does not decompress gzip on its own
+	awsReq.HTTPRequest.Header.Set("Accept-Encoding", "gzip, zstd")
+	if err := awsReq.Sign(); err != nil {
+		return nil, "", fmt.Errorf("s3Request: %w", err)
+	}
+
+	name := (&url.URL{Scheme: "s3", Host: bucket, Path: "/" + key}).String()
+
+	return awsReq.HTTPRequest, name, nil
+}
+
+// newestS3Object returns the key of the object under the prefix modified last.
+func newestS3Object(ctx context.Context, svc *s3.S3, bucket, prefix string) (string, error) {
+	var newest *s3.Object
+	err := svc.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, last bool) bool {
+		for _, obj := range page.Contents {
+			// folder placeholders
+			if strings.HasSuffix(aws.StringValue(obj.Key), "/") {
+				continue
+			}
+			if newest == nil || aws.TimeValue(obj.LastModified).After(aws.TimeValue(newest.LastModified)) {
+				newest = obj
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return "", fmt.Errorf("newestS3Object: %w", err)
+	}
+	if newest == nil {
+		return "", errors.NewErrNotFound(fmt.Errorf("newestS3Object: no objects in bucket %s under prefix %q", bucket, prefix))
+	}
+
+	return aws.StringValue(newest.Key), nil
+}
@@ -0,0 +1,255 @@
+package products
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+type fakeS3Object struct {
+	body         string
+	etag         string
+	lastModified time.Time
+}
+
+// fakeS3 serves path-style ListObjectsV2 and GetObject requests the way MinIO does.
+type fakeS3 struct {
+	bucket  string
+	objects map[string]fakeS3Object
+
+	mu sync.Mutex
+	// access key ids requests were signed with
+	keys []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if i := strings.Index(auth, "Credential="); i >= 0 {
+		f.mu.Lock()
+		f.keys = append(f.keys, strings.SplitN(auth[i+len("Credential="):], "/", 2)[0])
+		f.mu.Unlock()
+	}
+
+	path := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	if path[0] != f.bucket {
+		f.error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	if len(path) == 1 || path[1] == "" {
+		if r.URL.Query().Get("list-type") != "2" {
+			f.error(w, http.StatusNotImplemented, "NotImplemented")
+			return
+		}
+		f.list(w, r.URL.Query().Get("prefix"))
+		return
+	}
+
+	obj, ok := f.objects[path[1]]
+	if !ok {
+		f.error(w, http.StatusNotFound, "NoSuchKey")
+		return
+	}
+	if r.Header.Get("If-None-Match") == obj.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", obj.etag)
+	w.Header().Set("Last-Modified", obj.lastModified.Format(http.TimeFormat))
+	w.Header().Set("Content-Type", "text/csv")
+	fmt.Fprint(w, obj.body)
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, prefix string) {
+	var contents strings.Builder
+	for key, obj := range f.objects {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		fmt.Fprintf(&contents, "<Contents><Key>%s</Key><LastModified>%s</LastModified><ETag>%s</ETag><Size>%d</Size></Contents>",
+			key, obj.lastModified.Format(time.RFC3339), obj.etag, len(obj.body))
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>`+
+		`<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">`+
+		`<Name>%s</Name><Prefix>%s</Prefix><MaxKeys>1000</MaxKeys><IsTruncated>false</IsTruncated>%s</ListBucketResult>`,
+		f.bucket, prefix, contents.String())
+}
+
+func (f *fakeS3) error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+}
+
+func (f *fakeS3) lastKey() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.keys) == 0 {
+		return ""
+	}
+	return f.keys[len(f.keys)-1]
+}
+
+func TestS3List(t *testing.T) {
+	day := time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)
+	s3 := &fakeS3{
+		bucket: "feeds",
+		objects: map[string]fakeS3Object{
+			"daily/":               {lastModified: day.Add(48 * time.Hour)},
+			"daily/2021-01-14.csv": {body: "name;price\nfoo;1\n", etag: `"v1"`, lastModified: day.Add(-24 * time.Hour)},
+			"daily/2021-01-15.csv": {body: "name;price\nfoo;2\nbar;3\n", etag: `"v2"`, lastModified: day},
+			"daily/2021-01-13.csv": {body: "name;price\nfoo;0\n", etag: `"v0"`, lastModified: day.Add(-48 * time.Hour)},
+		},
+	}
+	srv := httptest.NewServer(s3)
+	defer srv.Close()
+
+	cli, err := NewClient(ClientConfig{
+		Egress: EgressPolicy{Schemes: []string{"http", "s3"}, AllowPrivate: true},
+		S3: S3Config{
+			Endpoint:        srv.URL,
+			Region:          "us-east-1",
+			AccessKeyID:     "minio",
+			SecretAccessKey: "minio123",
+			PathStyle:       true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	partner := Source{Auth: SourceAuth{Username: "partner", Password: Secret{Value: "secret"}}}
+
+	tests := []struct {
+		name    string
+		url     string
+		opts    []option
+		rows    []string
+		etag    string
+		key     string
+		wantErr error
+		code    codes.Code
+	}{
+		{
+			name: "object",
+			url:  "s3://feeds/daily/2021-01-14.csv",
+			rows: []string{"foo"},
+			etag: `"v1"`,
+			key:  "minio",
+		},
+		{
+			name: "newest object under prefix",
+			url:  "s3://feeds/daily/",
+			rows: []string{"foo", "bar"},
+			etag: `"v2"`,
+			key:  "minio",
+		},
+		{
+			name: "newest object under wildcard",
+			url:  "s3://feeds/daily/2021-01-1*",
+			rows: []string{"foo", "bar"},
+			etag: `"v2"`,
+			key:  "minio",
+		},
+		{
+			name: "source credentials",
+			url:  "s3://feeds/daily/",
+			opts: []option{partner.option()},
+			rows: []string{"foo", "bar"},
+			etag: `"v2"`,
+			key:  "partner",
+		},
+		{
+			name:    "not modified",
+			url:     "s3://feeds/daily/",
+			opts:    []option{Options().withFeedVersion(FeedVersion{ETag: `"v2"`})},
+			etag:    `"v2"`,
+			key:     "minio",
+			wantErr: errFeedNotModified,
+		},
+		{
+			name: "nothing under prefix",
+			url:  "s3://feeds/weekly/",
+			code: codes.NotFound,
+		},
+		{
+			name: "no such object",
+			url:  "s3://feeds/daily/2021-01-16.csv",
+			code: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []string
+			res, err := cli.List(context.Background(), tt.url, func(row FeedRow) error {
+				if row.Err != nil {
+					t.Fatalf("line %d: %v", row.Line, row.Err)
+				}
+				rows = append(rows, row.Product.Name)
+				return nil
+			}, tt.opts...)
+
+			switch {
+			case tt.wantErr != nil:
+				if !goErrors.Is(err, tt.wantErr) {
+					t.Fatalf("List() error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.code != codes.OK:
+				if code := egressCode(err); code != tt.code {
+					t.Fatalf("List() error = %v, want %s", err, tt.code)
+				}
+				return
+			case err != nil:
+				t.Fatalf("List() error = %v", err)
+			}
+
+			if strings.Join(rows, ",") != strings.Join(tt.rows, ",") {
+				t.Errorf("rows = %v, want %v", rows, tt.rows)
+			}
+			if res.Version.ETag != tt.etag {
+				t.Errorf("ETag = %s, want %s", res.Version.ETag, tt.etag)
+			}
+			if key := s3.lastKey(); key != tt.key {
+				t.Errorf("signed by %q, want %q", key, tt.key)
+			}
+		})
+	}
+}
+
+func TestS3CheckURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		schemes []string
+		url     string
+		code    codes.Code
+	}{
+		{name: "object", schemes: []string{"s3"}, url: "s3://feeds/daily.csv", code: codes.OK},
+		{name: "prefix", schemes: []string{"s3"}, url: "s3://feeds/daily/", code: codes.OK},
+		{name: "scheme not allowed", url: "s3://feeds/daily.csv", code: codes.InvalidArgument},
+		{name: "no bucket", schemes: []string{"s3"}, url: "s3:///daily.csv", code: codes.InvalidArgument},
+		{name: "no key", schemes: []string{"s3"}, url: "s3://feeds/", code: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, err := NewClient(ClientConfig{Egress: EgressPolicy{Schemes: tt.schemes}, S3: S3Config{Region: "us-east-1"}})
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			if code := egressCode(cli.CheckURL(tt.url)); code != tt.code {
+				t.Errorf("CheckURL(%s) = %s, want %s", tt.url, code, tt.code)
+			}
+		})
+	}
+}
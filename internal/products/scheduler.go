@@ -0,0 +1,103 @@
+package products
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+)
+
+const schedulerLease = "scheduler"
+
+// runScheduler fetches sources by their schedule while this instance holds the scheduler lease.
+// Each run is claimed in the storage as well, so a run is fired once even if two instances
+// believe they lead for a moment.
+// Runs missed while no instance was leading are fired once as soon as one is,
+// next run is counted from that moment. A run is skipped while the source is being fetched.
+func (s *service) runScheduler(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.ScheduleTick)
+	defer ticker.Stop()
+
+	for {
+		if err := s.scheduleTick(ctx); err != nil {
+			log.Printf("scheduler: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			s.releaseScheduler()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *service) scheduleTick(ctx context.Context) error {
+	// lease must not expire in the middle of the tick
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.ScheduleLease)
+	defer cancel()
+
+	leader, err := s.storage.AcquireLease(ctx, schedulerLease, s.instance, s.cfg.ScheduleLease)
+	if err != nil {
+		return fmt.Errorf("scheduleTick: %w", err)
+	}
+	if leader != s.instance {
+		return nil
+	}
+
+	now := time.Now().UTC()
+
+	ss, err := s.storage.FindDueSources(ctx, now)
+	if err != nil {
+		return fmt.Errorf("scheduleTick: %w", err)
+	}
+
+	for _, src := range ss {
+		if err := s.runScheduled(ctx, src, now); err != nil {
+			log.Printf("scheduler: source %s: %v", src.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *service) runScheduled(ctx context.Context, src Source, now time.Time) error {
+	claimed, err := s.storage.ClaimSourceRun(ctx, src.Name, src.NextRunAt, src.nextRun(now))
+	if err != nil {
+		return fmt.Errorf("runScheduled: %w", err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	var runErr string
+	reject, _ := Options().WithFetchConflict(ConflictReject)
+	job, err := s.Fetch(ctx, "", Options().WithSource(src.Name), reject)
+
+	var alreadyExists errors.ErrAlreadyExists
+	switch {
+	case goErrors.As(err, &alreadyExists):
+		runErr = fmt.Sprintf("skipped, the source is being fetched by job %s", job.ID)
+		// the job in flight is not the one of this run
+		job.ID = ""
+	case err != nil:
+		runErr = err.Error()
+	}
+	if err := s.storage.FinishSourceRun(ctx, src.Name, job.ID, runErr); err != nil {
+		return fmt.Errorf("runScheduled: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) releaseScheduler() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.FetchJobUpdateTimeout)
+	defer cancel()
+
+	if err := s.storage.ReleaseLease(ctx, schedulerLease, s.instance); err != nil {
+		log.Printf("scheduler: %v", err)
+	}
+}
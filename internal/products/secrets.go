@@ -0,0 +1,65 @@
+package products
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+)
+
+// secretBox encrypts secrets stored in the DB with AES-GCM, the key is derived from the configured passphrase.
+type secretBox struct {
+	aead cipher.AEAD
+}
+
+// newSecretBox returns nil box for empty passphrase, inline secrets can't be stored then.
+func newSecretBox(passphrase string) (*secretBox, error) {
+	if passphrase == "" {
+		return nil, nil
+	}
+
+	key := sha256.Sum256([]byte(passphrase))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("newSecretBox: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("newSecretBox: %w", err)
+	}
+
+	return &secretBox{aead}, nil
+}
+
+// seal returns nonce followed by the ciphertext.
+func (b *secretBox) seal(plaintext string) ([]byte, error) {
+	if b == nil {
+		return nil, fmt.Errorf("seal: secrets key is not configured, use secret files instead")
+	}
+
+	nonce := make([]byte, b.aead.NonceSize(), b.aead.NonceSize()+len(plaintext)+b.aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("seal: %w", err)
+	}
+
+	return b.aead.Seal(nonce, nonce, []byte(plaintext), nil), nil
+}
+
+func (b *secretBox) open(sealed []byte) (string, error) {
+	if b == nil {
+		return "", fmt.Errorf("open: secrets key is not configured")
+	}
+	if len(sealed) < b.aead.NonceSize() {
+		return "", fmt.Errorf("open: sealed secret is too short")
+	}
+
+	nonce, ciphertext := sealed[:b.aead.NonceSize()], sealed[b.aead.NonceSize():]
+	plaintext, err := b.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("open: %w", err)
+	}
+
+	return string(plaintext), nil
+}
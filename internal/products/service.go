@@ -3,42 +3,305 @@ package products
 import (
 	"context"
 	"fmt"
-	"net/url"
+	"os"
+	"time"
 
 	"github.com/marknovikov/products-demo/internal/errors"
 )
 
 type Service interface {
-	Fetch(ctx context.Context, path string) error
+	Fetch(ctx context.Context, path string, opts ...option) (FetchJob, error)
+	// DiffFetch runs the feed through the whole Fetch pipeline without writing anything, no fetch job is created.
+	DiffFetch(ctx context.Context, path string, opts ...option) (FetchDiff, error)
+	GetFetchJob(ctx context.Context, id string) (FetchJob, error)
+	ListFetchJobs(ctx context.Context, filter FetchJobsFilter) ([]FetchJob, error)
+	CancelFetchJob(ctx context.Context, id string) (FetchJob, error)
+	GetFetchJobErrors(ctx context.Context, id string, fn func(e RowError) error) error
 	List(ctx context.Context, opts ...option) ([]Product, error)
+	GetPriceHistory(ctx context.Context, filter PriceHistoryFilter) ([]PriceChange, error)
+	RestoreProduct(ctx context.Context, id string) (Product, error)
+	// CreateSource schedules the source fetches if Source.Schedule is set.
+	CreateSource(ctx context.Context, src Source) (Source, error)
+	// UpdateSource replaces the whole definition of the source with the same name.
+	UpdateSource(ctx context.Context, src Source) (Source, error)
+	// GetSource reports the source along with its last and next scheduled runs.
+	GetSource(ctx context.Context, name string) (Source, error)
+	ListSources(ctx context.Context, filter SourcesFilter) ([]Source, error)
+	DeleteSource(ctx context.Context, name string) error
+	// UploadFeed ingests the feed pushed by the caller the way Fetch ingests the downloaded one,
+	// the call blocks until the upload is read in full and ingested.
+	UploadFeed(ctx context.Context, name string, upload FeedUpload, opts ...option) (FetchJob, error)
+	FetchBatch(ctx context.Context, items []FetchBatchItem) ([]FetchBatchResult, error)
+	Close() error
+}
+
+type ServiceConfig struct {
+	// limits whole fetch job run but the time queued for a fetch worker, unlimited if zero
+	FetchTimeout time.Duration
+	// how often running fetch job reports it is alive and checks for cancellation
+	FetchJobHeartbeat time.Duration
+	// limits fetch job bookkeeping queries detached from the caller's context
+	FetchJobUpdateTimeout time.Duration
+	// caps per-row errors kept in the ingestion report
+	MaxReportErrors int
+	// products written to the storage at once
+	BatchSize int
+	// parsed batches waiting to be written, parsing blocks when the queue is full
+	BatchQueue int
+	// caps new products and price changes listed by dry run
+	MaxDiffItems int
+	// how often due scheduled fetches are checked, scheduler is off if zero
+	ScheduleTick time.Duration
+	// scheduler leadership lifetime, prolonged every tick, must be well above ScheduleTick
+	ScheduleLease time.Duration
+	// dir new feed files are picked up from, must be within the client file roots; watcher is off if empty
+	InboxDir string
+	// how often the inbox dir is checked for new files
+	InboxPoll time.Duration
+	// files modified more recently are considered still being written
+	InboxSettle time.Duration
+	// feeds downloaded at once by this instance, whoever started the fetch, unlimited if zero;
+	// fetch jobs stay pending until a worker is free
+	FetchWorkers int
+	// feeds downloaded at once by this instance from the same host, unlimited if zero
+	FetchWorkersPerHost int
+	// caps feeds of a single FetchBatch, unlimited if zero
+	MaxBatchFeeds int
 }
 
 type service struct {
-	client  Client
-	storage Storage
+	client   Client
+	storage  Storage
+	cfg      ServiceConfig
+	instance string
+	jobs     *jobRunner
+	pool     *fetchPool
+
+	stopScheduler context.CancelFunc
+	schedulerDone chan struct{}
+	stopInbox     context.CancelFunc
+	inboxDone     chan struct{}
+	stopReaper    context.CancelFunc
+	reaperDone    chan struct{}
+}
+
+func NewService(client Client, storage Storage, cfg ServiceConfig) Service {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	s := &service{
+		client:   client,
+		storage:  storage,
+		cfg:      cfg,
+		instance: fmt.Sprintf("%s:%d", host, os.Getpid()),
+		jobs:     newJobRunner(),
+		pool:     newFetchPool(cfg.FetchWorkers, cfg.FetchWorkersPerHost),
+	}
+
+	if cfg.ScheduleTick > 0 {
+		var ctx context.Context
+		ctx, s.stopScheduler = context.WithCancel(context.Background())
+		s.schedulerDone = make(chan struct{})
+		go func() {
+			defer close(s.schedulerDone)
+			s.runScheduler(ctx)
+		}()
+	}
+
+	if cfg.FetchJobHeartbeat > 0 {
+		var ctx context.Context
+		ctx, s.stopReaper = context.WithCancel(context.Background())
+		s.reaperDone = make(chan struct{})
+		go func() {
+			defer close(s.reaperDone)
+			s.runReaper(ctx)
+		}()
+	}
+
+	if cfg.InboxDir != "" {
+		var ctx context.Context
+		ctx, s.stopInbox = context.WithCancel(context.Background())
+		s.inboxDone = make(chan struct{})
+		go func() {
+			defer close(s.inboxDone)
+			s.runInbox(ctx)
+		}()
+	}
+
+	return s
 }
 
-func NewService(client Client, storage Storage) Service {
-	return &service{
-		client:  client,
-		storage: storage,
+func (s *service) Fetch(ctx context.Context, path string, opts ...option) (FetchJob, error) {
+	path, source, opts, err := s.resolveSource(ctx, path, opts)
+	if err != nil {
+		return FetchJob{}, fmt.Errorf("Fetch: %w", err)
+	}
+
+	job, err := s.fetchResolved(ctx, path, source, opts)
+	if err != nil {
+		return job, fmt.Errorf("Fetch: %w", err)
+	}
+
+	return job, nil
+}
+
+// fetchResolved starts the fetch job of the url and the source resolved by resolveSource,
+// opts are the ones returned by it.
+func (s *service) fetchResolved(ctx context.Context, path, source string, opts []option) (FetchJob, error) {
+	if s.jobs.closed() {
+		return FetchJob{}, errors.NewErrInternal(fmt.Errorf("fetchResolved: service is shutting down"))
+	}
+
+	optsHolder := applyOptions(opts)
+
+	job, started, err := s.lockFetch(ctx, FetchJob{URL: path, Source: source}, optsHolder.conflict)
+	if err != nil {
+		return job, fmt.Errorf("fetchResolved: %w", err)
+	}
+
+	var done <-chan struct{}
+	if started {
+		done = s.jobs.start(job.ID, func(ctx context.Context) {
+			s.runFetchJob(ctx, job, opts)
+		})
+	} else {
+		// joined job may be running on another instance
+		done = s.watchFetchJob(ctx, job.ID)
+	}
+
+	if !optsHolder.wait {
+		return job, nil
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return job, fmt.Errorf("fetchResolved: job %s is still running: %w", job.ID, ctx.Err())
+	}
+
+	job, err = s.storage.FindFetchJob(ctx, job.ID)
+	if err != nil {
+		return job, fmt.Errorf("fetchResolved: %w", err)
+	}
+	if job.State != FetchJobStateSucceeded {
+		return job, fmt.Errorf("fetchResolved: job %s %s: %s", job.ID, job.State, job.Error)
+	}
+
+	return job, nil
+}
+
+func (s *service) DiffFetch(ctx context.Context, path string, opts ...option) (FetchDiff, error) {
+	path, source, opts, err := s.resolveSource(ctx, path, opts)
+	if err != nil {
+		return FetchDiff{}, fmt.Errorf("DiffFetch: %w", err)
+	}
+
+	run := &fetchRun{
+		path:     path,
+		source:   source,
+		opts:     opts,
+		progress: &fetchProgress{},
+		diff:     &FetchDiff{},
+	}
+
+	release, err := s.fetchWorker(ctx, run)
+	if err != nil {
+		return FetchDiff{}, fmt.Errorf("DiffFetch: %w", err)
+	}
+	defer release()
+
+	if s.cfg.FetchTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.FetchTimeout)
+		defer cancel()
+	}
+
+	err = s.fetch(ctx, run)
+	run.diff.Report = run.progress.snapshot()
+	if err != nil {
+		return *run.diff, fmt.Errorf("DiffFetch: %w", err)
+	}
+
+	return *run.diff, nil
+}
+
+// resolveSource takes the url and options of the source given by WithSource, explicit options override the source ones.
+func (s *service) resolveSource(ctx context.Context, path string, opts []option) (string, string, []option, error) {
+	name := applyOptions(opts).source
+	if name == "" {
+		if err := s.client.CheckURL(path); err != nil {
+			return "", "", nil, fmt.Errorf("resolveSource: %w", err)
+		}
+		return path, "", opts, nil
+	}
+
+	if path != "" {
+		return "", "", nil, errors.NewErrInvalidInput(fmt.Errorf("resolveSource: both url and source %s given", name))
+	}
+
+	src, opts, err := s.sourceOptions(ctx, name, opts)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("resolveSource: %w", err)
+	}
+	if err := s.client.CheckURL(src.URL); err != nil {
+		return "", "", nil, fmt.Errorf("resolveSource: source %s: %w", name, err)
+	}
+
+	return src.URL, src.Name, opts, nil
+}
+
+// sourceOptions puts the options of the enabled source before the given ones.
+func (s *service) sourceOptions(ctx context.Context, name string, opts []option) (Source, []option, error) {
+	src, err := s.storage.FindSource(ctx, name)
+	if err != nil {
+		return src, nil, fmt.Errorf("sourceOptions: %w", err)
+	}
+	if !src.Enabled {
+		return src, nil, errors.NewErrInvalidInput(fmt.Errorf("sourceOptions: source %s is disabled", name))
 	}
+
+	return src, append([]option{src.option()}, opts...), nil
 }
 
-func (s *service) Fetch(ctx context.Context, path string) error {
-	_, err := url.Parse(path)
+func (s *service) GetFetchJob(ctx context.Context, id string) (FetchJob, error) {
+	job, err := s.storage.FindFetchJob(ctx, id)
 	if err != nil {
-		return errors.NewErrInvalidInput(fmt.Errorf("Fetch: %w", err))
+		return job, fmt.Errorf("GetFetchJob: %w", err)
 	}
 
-	pp, err := s.client.List(ctx, path)
+	return job, nil
+}
+
+func (s *service) ListFetchJobs(ctx context.Context, filter FetchJobsFilter) ([]FetchJob, error) {
+	jj, err := s.storage.FindFetchJobs(ctx, filter)
 	if err != nil {
-		return fmt.Errorf("Fetch: %w", err)
+		return jj, fmt.Errorf("ListFetchJobs: %w", err)
 	}
 
-	err = s.storage.UpdateProducts(ctx, pp)
+	return jj, nil
+}
+
+func (s *service) CancelFetchJob(ctx context.Context, id string) (FetchJob, error) {
+	job, err := s.storage.CancelFetchJob(ctx, id)
 	if err != nil {
-		return fmt.Errorf("Fetch: %w", err)
+		return job, fmt.Errorf("CancelFetchJob: %w", err)
+	}
+
+	// no need to wait for the heartbeat if the job runs right here
+	s.jobs.cancel(id)
+
+	return job, nil
+}
+
+func (s *service) GetFetchJobErrors(ctx context.Context, id string, fn func(e RowError) error) error {
+	if _, err := s.storage.FindFetchJob(ctx, id); err != nil {
+		return fmt.Errorf("GetFetchJobErrors: %w", err)
+	}
+
+	if err := s.storage.FindFetchJobErrors(ctx, id, fn); err != nil {
+		return fmt.Errorf("GetFetchJobErrors: %w", err)
 	}
 
 	return nil
@@ -52,3 +315,125 @@ func (s *service) List(ctx context.Context, opts ...option) ([]Product, error) {
 
 	return pp, nil
 }
+
+func (s *service) GetPriceHistory(ctx context.Context, filter PriceHistoryFilter) ([]PriceChange, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, errors.NewErrInvalidInput(fmt.Errorf("GetPriceHistory: from %s is not before to %s", filter.From, filter.To))
+	}
+
+	cc, err := s.storage.FindPriceChanges(ctx, filter)
+	if err != nil {
+		return cc, fmt.Errorf("GetPriceHistory: %w", err)
+	}
+
+	return cc, nil
+}
+
+// RestoreProduct brings archived product back to List, full sync archives it again if its feed keeps missing it.
+func (s *service) RestoreProduct(ctx context.Context, id string) (Product, error) {
+	p, err := s.storage.RestoreProduct(ctx, id)
+	if err != nil {
+		return p, fmt.Errorf("RestoreProduct: %w", err)
+	}
+
+	return p, nil
+}
+
+func (s *service) CreateSource(ctx context.Context, src Source) (Source, error) {
+	if err := src.Validate(); err != nil {
+		return Source{}, errors.NewErrInvalidInput(fmt.Errorf("CreateSource: %w", err))
+	}
+	if src.Auth.hasKept() {
+		return Source{}, errors.NewErrInvalidInput(fmt.Errorf("CreateSource: redacted secret has no stored value"))
+	}
+	src.NextRunAt = src.nextRun(time.Now())
+
+	src, err := s.storage.CreateSource(ctx, src)
+	if err != nil {
+		return src, fmt.Errorf("CreateSource: %w", err)
+	}
+
+	return src.redacted(), nil
+}
+
+func (s *service) UpdateSource(ctx context.Context, src Source) (Source, error) {
+	if err := src.Validate(); err != nil {
+		return Source{}, errors.NewErrInvalidInput(fmt.Errorf("UpdateSource: %w", err))
+	}
+	if src.Auth.hasKept() {
+		stored, err := s.storage.FindSource(ctx, src.Name)
+		if err != nil {
+			return Source{}, fmt.Errorf("UpdateSource: %w", err)
+		}
+		src.Auth = src.Auth.keep(stored.Auth)
+	}
+	// runs missed while the source was disabled are not fired
+	src.NextRunAt = src.nextRun(time.Now())
+
+	src, err := s.storage.UpdateSource(ctx, src)
+	if err != nil {
+		return src, fmt.Errorf("UpdateSource: %w", err)
+	}
+
+	// the same feed may give different products with the new definition
+	if err := s.storage.DeleteFeedVersion(ctx, feedKey(src.Name, "")); err != nil {
+		return src, fmt.Errorf("UpdateSource: %w", err)
+	}
+
+	return src.redacted(), nil
+}
+
+func (s *service) GetSource(ctx context.Context, name string) (Source, error) {
+	src, err := s.storage.FindSource(ctx, name)
+	if err != nil {
+		return src, fmt.Errorf("GetSource: %w", err)
+	}
+
+	return src.redacted(), nil
+}
+
+func (s *service) ListSources(ctx context.Context, filter SourcesFilter) ([]Source, error) {
+	ss, err := s.storage.FindSources(ctx, filter)
+	if err != nil {
+		return ss, fmt.Errorf("ListSources: %w", err)
+	}
+
+	for i := range ss {
+		ss[i] = ss[i].redacted()
+	}
+
+	return ss, nil
+}
+
+// DeleteSource keeps products priced by the source, they are archived by no one anymore.
+func (s *service) DeleteSource(ctx context.Context, name string) error {
+	if err := s.storage.DeleteSource(ctx, name); err != nil {
+		return fmt.Errorf("DeleteSource: %w", err)
+	}
+
+	if err := s.storage.DeleteFeedVersion(ctx, feedKey(name, "")); err != nil {
+		return fmt.Errorf("DeleteSource: %w", err)
+	}
+
+	return nil
+}
+
+// Close stops the scheduler, the inbox watcher and the reaper, cancels fetch jobs running on this instance and waits for them to record their state.
+func (s *service) Close() error {
+	if s.stopScheduler != nil {
+		s.stopScheduler()
+		<-s.schedulerDone
+	}
+	if s.stopInbox != nil {
+		s.stopInbox()
+		<-s.inboxDone
+	}
+	if s.stopReaper != nil {
+		s.stopReaper()
+		<-s.reaperDone
+	}
+
+	s.jobs.stop()
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package products
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Source is a named feed definition, Fetch by source name takes its url and options.
+type Source struct {
+	Name    string
+	URL     string
+	Format  FeedFormat
+	Schema  FeedSchema
+	Archive ArchiveOptions
+	// sent with every feed request, shown as is, credentials go to Auth
+	Headers     map[string]string
+	Auth        SourceAuth
+	ErrorPolicy ErrorPolicy
+	Sync        SyncPolicy
+	// disabled sources can't be fetched
+	Enabled bool
+	// cron expression in UTC, the source is fetched by schedule if set
+	Schedule  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// scheduled runs state, maintained by the service
+	NextRunAt time.Time
+	LastRunAt time.Time
+	// job started by the last scheduled run
+	LastJobID string
+	// why the last scheduled run did not start, empty if it did
+	LastRunError string
+}
+
+func (s Source) Validate() error {
+	if strings.TrimSpace(s.Name) == "" {
+		return fmt.Errorf("Validate: empty source name")
+	}
+
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return fmt.Errorf("Validate: url: %w", err)
+	}
+	// file:///path has no host
+	if u.Scheme == "" || (u.Host == "" && !isFileURL(u)) {
+		return fmt.Errorf("Validate: absolute url expected, got: %s", s.URL)
+	}
+
+	for name := range s.Headers {
+		if strings.TrimSpace(name) == "" || strings.ContainsAny(name, " :\r\n") {
+			return fmt.Errorf("Validate: invalid header name: %q", name)
+		}
+	}
+
+	if err := s.Auth.Validate(); err != nil {
+		return fmt.Errorf("Validate: auth: %w", err)
+	}
+	if err := s.Format.Validate(); err != nil {
+		return fmt.Errorf("Validate: %w", err)
+	}
+	if err := s.Schema.Validate(); err != nil {
+		return fmt.Errorf("Validate: %w", err)
+	}
+	if err := s.Archive.Validate(); err != nil {
+		return fmt.Errorf("Validate: %w", err)
+	}
+	if err := s.ErrorPolicy.Validate(); err != nil {
+		return fmt.Errorf("Validate: %w", err)
+	}
+	if err := s.Sync.Validate(); err != nil {
+		return fmt.Errorf("Validate: %w", err)
+	}
+	if s.Schedule != "" {
+		if _, err := parseCron(s.Schedule); err != nil {
+			return fmt.Errorf("Validate: schedule: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// option applies the source definition, options following it override the definition.
+func (s Source) option() option {
+	return func(opts *optsHolder) {
+		opts.format = s.Format
+		opts.schema = s.Schema
+		opts.archive = s.Archive
+		opts.headers = s.Headers
+		opts.auth = s.Auth
+		opts.errorPolicy = s.ErrorPolicy
+		opts.sync = s.Sync
+	}
+}
+
+// redacted hides inline secrets, the source is shown this way only.
+func (s Source) redacted() Source {
+	s.Auth = s.Auth.redacted()
+	return s
+}
+
+type SourcesFilter struct {
+	Limit    uint32
+	LastName string
+}
+
+// nextRun returns the next scheduled run after t, zero time if the source is not scheduled.
+func (s Source) nextRun(t time.Time) time.Time {
+	if s.Schedule == "" {
+		return time.Time{}
+	}
+
+	sched, err := parseCron(s.Schedule)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return sched.next(t)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	goErrors "errors"
 	"fmt"
+	"log"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -16,10 +18,62 @@ import (
 )
 
 type Storage interface {
-	UpdateProducts(ctx context.Context, pp []Product) error
+	// UpdateProducts records every price change along with its origin to the price history.
+	UpdateProducts(ctx context.Context, pp []Product, origin PriceOrigin) (UpdateResult, error)
 	FindProducts(ctx context.Context, opts ...option) ([]Product, error)
+	FindProductsByName(ctx context.Context, names []string) ([]Product, error)
+	// ArchiveMissingProducts archives products of the source not listed by the job
+	// for SyncPolicy.MissedFetches consecutive calls.
+	ArchiveMissingProducts(ctx context.Context, origin PriceOrigin, policy SyncPolicy) (archived uint32, err error)
+	// KeepListed marks products of the source as listed by the job leaving them as they are otherwise,
+	// so ArchiveMissingProducts does not take products of the rejected rows for missing.
+	KeepListed(ctx context.Context, names []string, origin PriceOrigin) error
+	RestoreProduct(ctx context.Context, id string) (Product, error)
+	FindPriceChanges(ctx context.Context, filter PriceHistoryFilter) ([]PriceChange, error)
+
+	CreateSource(ctx context.Context, src Source) (Source, error)
+	UpdateSource(ctx context.Context, src Source) (Source, error)
+	FindSource(ctx context.Context, name string) (Source, error)
+	FindSources(ctx context.Context, filter SourcesFilter) ([]Source, error)
+	DeleteSource(ctx context.Context, name string) error
+	FindFeedVersion(ctx context.Context, key string) (FeedVersion, error)
+	SaveFeedVersion(ctx context.Context, key string, v FeedVersion) error
+	DeleteFeedVersion(ctx context.Context, key string) error
+
+	// FindDueSources returns enabled sources with scheduled run at or before now.
+	FindDueSources(ctx context.Context, now time.Time) ([]Source, error)
+	ClaimSourceRun(ctx context.Context, name string, due, next time.Time) (claimed bool, err error)
+	FinishSourceRun(ctx context.Context, name, jobID, runErr string) error
+
+	// AcquireLease returns the holder of the lease, which is the given one if acquired.
+	AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (current string, err error)
+	ReleaseLease(ctx context.Context, name, holder string) error
+
+	CreateFetchJob(ctx context.Context, job FetchJob) (FetchJob, error)
+	DeleteFetchJob(ctx context.Context, id string) error
+	StartFetchJob(ctx context.Context, id, instance string) (started bool, err error)
+	HeartbeatFetchJob(ctx context.Context, id string, progress IngestionReport) (cancelRequested bool, err error)
+	FinishFetchJob(ctx context.Context, job FetchJob) error
+	// FailStaleFetchJobs fails unfinished jobs not heard of since seenBefore, their instances are gone.
+	FailStaleFetchJobs(ctx context.Context, seenBefore time.Time) (failed int64, err error)
+	FindFetchJob(ctx context.Context, id string) (FetchJob, error)
+	FindFetchJobs(ctx context.Context, filter FetchJobsFilter) ([]FetchJob, error)
+	CancelFetchJob(ctx context.Context, id string) (FetchJob, error)
+	InsertFetchJobErrors(ctx context.Context, id string, ee []RowError) error
+	FindFetchJobErrors(ctx context.Context, id string, fn func(e RowError) error) error
 }
 
+const (
+	productsCollection       = "products"
+	fetchJobsCollection      = "fetchJobs"
+	fetchJobErrorsCollection = "fetchJobErrors"
+	priceHistoryCollection   = "priceHistory"
+	sourcesCollection        = "sources"
+	leasesCollection         = "leases"
+	feedVersionsCollection   = "feedVersions"
+	migrationsCollection     = "migrations"
+)
+
 type StorageConfig struct {
 	Host            string
 	Port            int
@@ -30,6 +84,8 @@ type StorageConfig struct {
 	QueryTimeout    time.Duration
 	MaxConns        uint32
 	IdleConnTimeout time.Duration
+	// passphrase inline source secrets are encrypted with, they can't be stored if empty
+	SecretsKey string
 }
 
 func (cfg StorageConfig) connString() string {
@@ -37,8 +93,9 @@ func (cfg StorageConfig) connString() string {
 }
 
 type mongodb struct {
-	cli *mongo.Client
-	cfg StorageConfig
+	cli     *mongo.Client
+	cfg     StorageConfig
+	secrets *secretBox
 }
 
 type mongoProduct struct {
@@ -47,6 +104,11 @@ type mongoProduct struct {
 	Price            primitive.Decimal128 `bson:"price,omitempty"`
 	PriceUpdateCount uint32               `bson:"priceUpdateCount,omitempty"`
 	LastModified     time.Time            `bson:"lastModified,omitempty"`
+	ExternalID       string               `bson:"externalId,omitempty"`
+	Currency         string               `bson:"currency,omitempty"`
+	Source           string               `bson:"source,omitempty"`
+	Archived         bool                 `bson:"archived,omitempty"`
+	ArchivedAt       time.Time            `bson:"archivedAt,omitempty"`
 }
 
 func newMongoProduct(p Product) (mongoProduct, error) {
@@ -69,6 +131,11 @@ func newMongoProduct(p Product) (mongoProduct, error) {
 		Price:            price,
 		PriceUpdateCount: p.PriceUpdateCount,
 		LastModified:     p.LastModified,
+		ExternalID:       p.ExternalID,
+		Currency:         p.Currency,
+		Source:           p.Source,
+		Archived:         p.Archived,
+		ArchivedAt:       p.ArchivedAt,
 	}, nil
 }
 
@@ -90,12 +157,28 @@ func (p mongoProduct) updateFilter() bson.D {
 		bson.D{{"price", bson.D{{"$not", bson.D{{"$eq", p.Price}}}}}}}}}
 }
 
-func (p mongoProduct) updateQuery() bson.D {
+func (p mongoProduct) updateQuery(now time.Time) bson.D {
 	return bson.D{
 		{"$setOnInsert", bson.D{{"name", p.Name}}},
-		{"$set", bson.D{{"price", p.Price}}},
+		{"$set", bson.D{{"price", p.Price}, {"externalId", p.ExternalID}, {"currency", p.Currency}}},
 		{"$inc", bson.D{{"priceUpdateCount", 1}}},
-		{"$set", bson.D{{"lastModified", primitive.NewDateTimeFromTime(time.Now().UTC())}}},
+		{"$set", bson.D{{"lastModified", primitive.NewDateTimeFromTime(now)}}},
+	}
+}
+
+// priceChange is the history record of the update, if it was applied.
+func (p mongoProduct) priceChange(event PriceEvent, id primitive.ObjectID, old *primitive.Decimal128, now time.Time, origin PriceOrigin) mongoPriceChange {
+	return mongoPriceChange{
+		Event:      string(event),
+		ProductID:  id,
+		Name:       p.Name,
+		OldPrice:   old,
+		NewPrice:   p.Price,
+		Currency:   p.Currency,
+		ExternalID: p.ExternalID,
+		ChangedAt:  now,
+		Source:     origin.Source,
+		JobID:      origin.JobID,
 	}
 }
 
@@ -111,6 +194,11 @@ func (p mongoProduct) toProduct() (Product, error) {
 		Price:            price,
 		PriceUpdateCount: p.PriceUpdateCount,
 		LastModified:     p.LastModified,
+		ExternalID:       p.ExternalID,
+		Currency:         p.Currency,
+		Source:           p.Source,
+		Archived:         p.Archived,
+		ArchivedAt:       p.ArchivedAt,
 	}, nil
 }
 
@@ -141,29 +229,91 @@ func NewMongoConn(cfg StorageConfig) (cli *mongo.Client, close func() error, err
 		return nil, nil, fmt.Errorf("NewMongoConn: Ping: %w", err)
 	}
 
+	if err := initIndexes(cli, cfg); err != nil {
+		_ = closeMongoCli(cli, cfg.ConnTimeout)
+
+		return nil, nil, fmt.Errorf("NewMongoConn: %w", err)
+	}
+
+	// migrations may take long on big collections, so they run in the background without the connect timeout
+	// and are retried on the next start if they fail
+	migrateCtx, stopMigrations := context.WithCancel(context.Background())
+	var migrating sync.WaitGroup
+	migrating.Add(1)
+	go func() {
+		defer migrating.Done()
+		if err := migrate(migrateCtx, cli, cfg); err != nil {
+			log.Printf("storage: %v", err)
+		}
+	}()
+
 	closer := func() error {
+		stopMigrations()
+		migrating.Wait()
 		return closeMongoCli(cli, cfg.ConnTimeout)
 	}
 
-	ctx, cancel = context.WithTimeout(context.Background(), cfg.ConnTimeout)
-	defer cancel()
+	return cli, closer, nil
+}
 
-	if err := initIndexes(cli, cfg); err != nil {
-		_ = closeMongoCli(cli, cfg.ConnTimeout)
+// migrations run once per database in this order, each is recorded in migrationsCollection once it completes.
+// They may run on several instances at once, so they have to be idempotent.
+var migrations = []struct {
+	name string
+	run  func(ctx context.Context, db *mongo.Database) error
+}{
+	{"seedPriceHistory", seedPriceHistory},
+}
 
-		return nil, nil, fmt.Errorf("NewMongoConn: %w", err)
+func migrate(ctx context.Context, cli *mongo.Client, cfg StorageConfig) error {
+	db := cli.Database(cfg.Database)
+	coll := db.Collection(migrationsCollection)
+
+	for _, m := range migrations {
+		err := coll.FindOne(ctx, bson.D{{"_id", m.name}}).Err()
+		if err == nil {
+			continue
+		}
+		if !goErrors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("migrate: %s: %w", m.name, err)
+		}
+
+		if err := m.run(ctx, db); err != nil {
+			return fmt.Errorf("migrate: %w", err)
+		}
+
+		_, err = coll.UpdateOne(ctx,
+			bson.D{{"_id", m.name}},
+			bson.D{{"$setOnInsert", bson.D{{"doneAt", time.Now().UTC()}}}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return fmt.Errorf("migrate: %s: %w", m.name, err)
+		}
 	}
 
-	return cli, closer, nil
+	return nil
 }
 
 func initIndexes(cli *mongo.Client, cfg StorageConfig) error {
-	coll := cli.Database(cfg.Database).Collection("products")
-
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.ConnTimeout)
 	defer cancel()
 
-	ii := []mongo.IndexModel{
+	for collName, ii := range collectionIndexes {
+		coll := cli.Database(cfg.Database).Collection(collName)
+
+		for _, idx := range ii {
+			if _, err := coll.Indexes().CreateOne(ctx, idx); err != nil {
+				return fmt.Errorf("initIndexes: create %s: %w", *idx.Options.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+var collectionIndexes = map[string][]mongo.IndexModel{
+	productsCollection: {
 		{
 			Keys:    bson.D{{"name", 1}},
 			Options: options.Index().SetUnique(true).SetName("productsNameUniqueIdx"),
@@ -180,64 +330,185 @@ func initIndexes(cli *mongo.Client, cfg StorageConfig) error {
 			Keys:    bson.D{{"lastModified", 1}},
 			Options: options.Index().SetName("productsLastModifiedIdx"),
 		},
-	}
-
-	for _, idx := range ii {
-		if _, err := coll.Indexes().CreateOne(ctx, idx); err != nil {
-			return fmt.Errorf("initIndexes: create %s: %w", *idx.Options.Name, err)
-		}
-	}
-
-	return nil
+		{
+			Keys:    bson.D{{"source", 1}, {"lastListedJobId", 1}},
+			Options: options.Index().SetName("productsSourceIdx"),
+		},
+	},
+	fetchJobsCollection: {
+		{
+			Keys:    bson.D{{"state", 1}, {"_id", -1}},
+			Options: options.Index().SetName("fetchJobsStateIdx"),
+		},
+	},
+	fetchJobErrorsCollection: {
+		{
+			Keys:    bson.D{{"jobId", 1}, {"line", 1}},
+			Options: options.Index().SetName("fetchJobErrorsJobLineIdx"),
+		},
+	},
+	priceHistoryCollection: {
+		{
+			Keys:    bson.D{{"productId", 1}, {"changedAt", 1}, {"_id", 1}},
+			Options: options.Index().SetName("priceHistoryProductChangedAtIdx"),
+		},
+		{
+			Keys:    bson.D{{"name", 1}, {"changedAt", 1}, {"_id", 1}},
+			Options: options.Index().SetName("priceHistoryNameChangedAtIdx"),
+		},
+		{
+			Keys:    bson.D{{"changedAt", 1}},
+			Options: options.Index().SetName("priceHistoryChangedAtIdx"),
+		},
+	},
+	sourcesCollection: {
+		{
+			Keys:    bson.D{{"name", 1}},
+			Options: options.Index().SetUnique(true).SetName("sourcesNameUniqueIdx"),
+		},
+		{
+			Keys:    bson.D{{"nextRunAt", 1}},
+			Options: options.Index().SetName("sourcesNextRunAtIdx"),
+		},
+	},
 }
 
 func NewMongoStorage(cli *mongo.Client, cfg StorageConfig) (Storage, error) {
+	secrets, err := newSecretBox(cfg.SecretsKey)
+	if err != nil {
+		return nil, fmt.Errorf("NewMongoStorage: %w", err)
+	}
+
 	return &mongodb{
-		cli: cli,
-		cfg: cfg,
+		cli:     cli,
+		cfg:     cfg,
+		secrets: secrets,
 	}, nil
 }
 
-func (s *mongodb) UpdateProducts(ctx context.Context, pp []Product) error {
-	coll := s.cli.Database(s.cfg.Database).Collection("products")
+func (s *mongodb) UpdateProducts(ctx context.Context, pp []Product, origin PriceOrigin) (UpdateResult, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(productsCollection)
+
+	var res UpdateResult
+	if len(pp) == 0 {
+		return res, nil
+	}
+
+	now := time.Now().UTC()
 
-	writeModel := make([]mongo.WriteModel, len(pp))
+	mpp := make([]mongoProduct, len(pp))
+	// positions in pp of the products written, the first of the same name only
+	written := make([]int, 0, len(pp))
+	writeModel := make([]mongo.WriteModel, 0, len(pp))
+	seen := make(map[string]bool, len(pp))
 	for i := range pp {
 		p, err := newMongoProduct(pp[i])
 		if err != nil {
-			return fmt.Errorf("UpdateProducts %w", err)
+			return res, fmt.Errorf("UpdateProducts %w", err)
 		}
+		mpp[i] = p
 
-		writeModel[i] = mongo.NewUpdateOneModel().
+		// the same name twice in a single unordered bulk write races for the unique name index
+		if seen[p.Name] {
+			res.reject(i, duplicateNameReason(p.Name))
+			continue
+		}
+		seen[p.Name] = true
+
+		written = append(written, i)
+		writeModel = append(writeModel, mongo.NewUpdateOneModel().
 			SetFilter(p.updateFilter()).
-			SetUpdate(p.updateQuery()).
-			SetUpsert(true)
+			SetUpdate(p.updateQuery(now)).
+			SetUpsert(true))
+	}
+
+	// prices may change in between with concurrent fetches, the history then misses the intermediate price
+	current, err := s.findCurrentPrices(ctx, mpp)
+	if err != nil {
+		return res, fmt.Errorf("UpdateProducts: %w", err)
 	}
 
 	opts := options.BulkWrite().
 		SetOrdered(false)
 
-	_, err := coll.BulkWrite(ctx, writeModel, opts)
-	if err != nil && !isErrDuplicateKey(err) {
-		return fmt.Errorf("UpdateProducts: %w", err)
+	bulkRes, err := coll.BulkWrite(ctx, writeModel, opts)
+	if bulkRes != nil {
+		res.Inserted = uint32(bulkRes.UpsertedCount)
+		res.Repriced = uint32(bulkRes.ModifiedCount)
 	}
 
-	return nil
-}
+	var bulkErr mongo.BulkWriteException
+	if err != nil && (!goErrors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil) {
+		return res, fmt.Errorf("UpdateProducts: %w", err)
+	}
 
-func isErrDuplicateKey(err error) bool {
-	var e mongo.BulkWriteException
+	failed := make(map[int]bool, len(bulkErr.WriteErrors))
+	for _, we := range bulkErr.WriteErrors {
+		failed[we.Index] = true
+	}
 
-	if !goErrors.As(err, &e) {
-		return false
+	var changes []mongoPriceChange
+	for w, i := range written {
+		if failed[w] {
+			continue
+		}
+		p := mpp[i]
+		if bulkRes != nil {
+			if id, ok := bulkRes.UpsertedIDs[int64(w)].(primitive.ObjectID); ok {
+				changes = append(changes, p.priceChange(PriceEventPrice, id, nil, now, origin))
+				continue
+			}
+		}
+		if cur, ok := current[p.Name]; ok {
+			old := cur.Price
+			changes = append(changes, p.priceChange(PriceEventPrice, cur.ID, &old, now, origin))
+		}
+	}
+	if err := s.insertPriceChanges(ctx, changes); err != nil {
+		return res, fmt.Errorf("UpdateProducts: %w", err)
 	}
 
-	for _, we := range e.WriteErrors {
-		if we.Code == 11000 {
-			return true
+	for _, we := range bulkErr.WriteErrors {
+		i := written[we.Index]
+		// update filter does not match product with the same name and price,
+		// so upsert attempt hits unique name index; anything else hitting it,
+		// e.g. the product inserted by a concurrent fetch, is not known to be stored
+		if we.Code == errCodeDuplicateKey {
+			if cur, ok := current[mpp[i].Name]; ok && samePrice(cur.Price, mpp[i].Price) {
+				res.Unchanged++
+				continue
+			}
 		}
+
+		res.reject(i, we.Message)
+	}
+
+	var listed []string
+	for i, p := range mpp {
+		if _, ok := res.Rejected[i]; !ok {
+			listed = append(listed, p.Name)
+		}
+	}
+	res.Restored, err = s.markListed(ctx, listed, now, origin)
+	if err != nil {
+		return res, fmt.Errorf("UpdateProducts: %w", err)
+	}
+
+	return res, nil
+}
+
+const errCodeDuplicateKey = 11000
+
+func samePrice(a, b primitive.Decimal128) bool {
+	da, err := decimal.NewFromString(a.String())
+	if err != nil {
+		return false
 	}
-	return false
+	db, err := decimal.NewFromString(b.String())
+	if err != nil {
+		return false
+	}
+	return da.Equal(db)
 }
 
 func mongoFindFilterOpts(opts ...option) (filter bson.D, mongoOpts *options.FindOptions, err error) {
@@ -253,6 +524,10 @@ func mongoFindFilterOpts(opts ...option) (filter bson.D, mongoOpts *options.Find
 		mongoOpts.SetLimit(int64(optsHolder.paging.Limit))
 	}
 
+	if !optsHolder.archived {
+		filter = append(filter, bson.E{"archived", bson.D{{"$ne", true}}})
+	}
+
 	seekPageFilter, err := resolveSeekPageFilter(optsHolder)
 	if err != nil {
 		return nil, nil, fmt.Errorf("mongoFindFilterOpts: %w", err)
@@ -341,7 +616,15 @@ var optsToSeekPageFilterStrategy = map[string]seekPageFilterStrategy{
 }
 
 func (s *mongodb) FindProducts(ctx context.Context, opts ...option) ([]Product, error) {
-	coll := s.cli.Database(s.cfg.Database).Collection("products")
+	if asOf := applyOptions(opts).asOf; asOf != nil {
+		pp, err := s.findProductsAsOf(ctx, *asOf, opts...)
+		if err != nil {
+			return pp, fmt.Errorf("FindProducts: %w", err)
+		}
+		return pp, nil
+	}
+
+	coll := s.cli.Database(s.cfg.Database).Collection(productsCollection)
 
 	filter, mongoOpts, err := mongoFindFilterOpts(opts...)
 	if err != nil {
@@ -374,6 +657,27 @@ func (s *mongodb) FindProducts(ctx context.Context, opts ...option) ([]Product,
 	return pp, nil
 }
 
+func (s *mongodb) FindProductsByName(ctx context.Context, names []string) ([]Product, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	mpp, err := s.findProducts(ctx, bson.D{{"name", bson.D{{"$in", names}}}})
+	if err != nil {
+		return nil, fmt.Errorf("FindProductsByName: %w", err)
+	}
+
+	pp := make([]Product, len(mpp))
+	for i, mp := range mpp {
+		pp[i], err = mp.toProduct()
+		if err != nil {
+			return nil, fmt.Errorf("FindProductsByName: %w", err)
+		}
+	}
+
+	return pp, nil
+}
+
 func closeMongoCli(cli *mongo.Client, connTimeout time.Duration) error {
 	ctx, cancel := context.WithTimeout(context.Background(), connTimeout)
 	defer cancel()
@@ -0,0 +1,324 @@
+package products
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+)
+
+type mongoPriceChange struct {
+	ID        primitive.ObjectID    `bson:"_id,omitempty"`
+	Event     string                `bson:"event,omitempty"`
+	ProductID primitive.ObjectID    `bson:"productId"`
+	Name      string                `bson:"name"`
+	OldPrice  *primitive.Decimal128 `bson:"oldPrice,omitempty"`
+	NewPrice  primitive.Decimal128  `bson:"newPrice"`
+	Currency  string                `bson:"currency,omitempty"`
+	// kept to rebuild the catalog as of any moment
+	ExternalID string    `bson:"externalId,omitempty"`
+	ChangedAt  time.Time `bson:"changedAt"`
+	Source     string    `bson:"source,omitempty"`
+	JobID      string    `bson:"jobId,omitempty"`
+}
+
+func (c mongoPriceChange) toPriceChange() (PriceChange, error) {
+	newPrice, err := decimal.NewFromString(c.NewPrice.String())
+	if err != nil {
+		return PriceChange{}, fmt.Errorf("toPriceChange: %w", err)
+	}
+
+	change := PriceChange{
+		ID:        c.ID.Hex(),
+		Event:     PriceEvent(c.Event),
+		ProductID: c.ProductID.Hex(),
+		Name:      c.Name,
+		NewPrice:  newPrice,
+		Currency:  c.Currency,
+		ChangedAt: c.ChangedAt,
+		Source:    c.Source,
+		JobID:     c.JobID,
+	}
+
+	// recorded before archiving was introduced
+	if change.Event == "" {
+		change.Event = PriceEventPrice
+	}
+
+	if c.OldPrice != nil {
+		oldPrice, err := decimal.NewFromString(c.OldPrice.String())
+		if err != nil {
+			return PriceChange{}, fmt.Errorf("toPriceChange: %w", err)
+		}
+		change.OldPrice = &oldPrice
+	}
+
+	return change, nil
+}
+
+// findCurrentPrices reads stored products by name, so price changes can be told after the bulk write.
+func (s *mongodb) findCurrentPrices(ctx context.Context, pp []mongoProduct) (map[string]mongoProduct, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(productsCollection)
+
+	names := make(bson.A, len(pp))
+	for i, p := range pp {
+		names[i] = p.Name
+	}
+
+	mongoOpts := options.Find().SetProjection(bson.D{{"name", 1}, {"price", 1}})
+
+	curs, err := coll.Find(ctx, bson.D{{"name", bson.D{{"$in", names}}}}, mongoOpts)
+	if err != nil {
+		return nil, fmt.Errorf("findCurrentPrices: %w", err)
+	}
+	defer curs.Close(ctx)
+
+	current := make(map[string]mongoProduct, len(pp))
+	for curs.Next(ctx) {
+		var p mongoProduct
+		if err := curs.Decode(&p); err != nil {
+			return nil, fmt.Errorf("findCurrentPrices: %w", err)
+		}
+		current[p.Name] = p
+	}
+	if err := curs.Err(); err != nil {
+		return nil, fmt.Errorf("findCurrentPrices: %w", err)
+	}
+
+	return current, nil
+}
+
+func (s *mongodb) insertPriceChanges(ctx context.Context, cc []mongoPriceChange) error {
+	if len(cc) == 0 {
+		return nil
+	}
+
+	coll := s.cli.Database(s.cfg.Database).Collection(priceHistoryCollection)
+
+	docs := make([]interface{}, len(cc))
+	for i := range cc {
+		docs[i] = cc[i]
+	}
+
+	if _, err := coll.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false)); err != nil {
+		return fmt.Errorf("insertPriceChanges: %w", err)
+	}
+
+	return nil
+}
+
+func (s *mongodb) FindPriceChanges(ctx context.Context, filter PriceHistoryFilter) ([]PriceChange, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(priceHistoryCollection)
+
+	mongoFilter := bson.D{}
+	if filter.ProductID != "" {
+		productID, err := primitive.ObjectIDFromHex(filter.ProductID)
+		if err != nil {
+			return nil, errors.NewErrInvalidInput(fmt.Errorf("FindPriceChanges: product id %s: %w", filter.ProductID, err))
+		}
+		mongoFilter = append(mongoFilter, bson.E{"productId", productID})
+	}
+	if filter.Name != "" {
+		mongoFilter = append(mongoFilter, bson.E{"name", filter.Name})
+	}
+	if filter.LastID != "" {
+		lastID, err := primitive.ObjectIDFromHex(filter.LastID)
+		if err != nil {
+			return nil, errors.NewErrInvalidInput(fmt.Errorf("FindPriceChanges: last id %s: %w", filter.LastID, err))
+		}
+
+		// ids made by different instances within a second are not ordered by time, so the page goes on after
+		// the last change time and only the changes made at the same time are told apart by id
+		var last mongoPriceChange
+		err = coll.FindOne(ctx, bson.D{{"_id", lastID}}, options.FindOne().SetProjection(bson.D{{"changedAt", 1}})).Decode(&last)
+		if goErrors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.NewErrInvalidInput(fmt.Errorf("FindPriceChanges: last id %s: not found", filter.LastID))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("FindPriceChanges: %w", err)
+		}
+		mongoFilter = append(mongoFilter, bson.E{"$or", bson.A{
+			bson.D{{"changedAt", bson.D{{"$gt", last.ChangedAt}}}},
+			bson.D{{"changedAt", last.ChangedAt}, {"_id", bson.D{{"$gt", lastID}}}},
+		}})
+	}
+
+	changedAt := bson.D{}
+	if !filter.From.IsZero() {
+		changedAt = append(changedAt, bson.E{"$gte", filter.From})
+	}
+	if !filter.To.IsZero() {
+		changedAt = append(changedAt, bson.E{"$lt", filter.To})
+	}
+	if len(changedAt) > 0 {
+		mongoFilter = append(mongoFilter, bson.E{"changedAt", changedAt})
+	}
+
+	mongoOpts := options.Find().SetSort(bson.D{{"changedAt", 1}, {"_id", 1}})
+	if filter.Limit > 0 {
+		mongoOpts.SetLimit(int64(filter.Limit))
+	}
+
+	curs, err := coll.Find(ctx, mongoFilter, mongoOpts)
+	if err != nil {
+		return nil, fmt.Errorf("FindPriceChanges: %w", err)
+	}
+	defer curs.Close(ctx)
+
+	var cc []PriceChange
+	for curs.Next(ctx) {
+		var mc mongoPriceChange
+		if err := curs.Decode(&mc); err != nil {
+			return cc, fmt.Errorf("FindPriceChanges: %w", err)
+		}
+
+		change, err := mc.toPriceChange()
+		if err != nil {
+			return cc, fmt.Errorf("FindPriceChanges: %w", err)
+		}
+		cc = append(cc, change)
+	}
+	if err := curs.Err(); err != nil {
+		return cc, fmt.Errorf("FindPriceChanges: %w", err)
+	}
+
+	return cc, nil
+}
+
+// seedPriceHistory records the current price of every product written before the price history was kept
+// as its first change made at the product last modification, so the catalog rebuilt as of any later moment lists it.
+// The record takes the product id, so it is seeded once whoever runs it. Products modified after the earliest
+// recorded change have their history already, they are not looked up. $merge needs MongoDB 4.2 or later.
+func seedPriceHistory(ctx context.Context, db *mongo.Database) error {
+	candidates := bson.D{}
+	var first mongoPriceChange
+	err := db.Collection(priceHistoryCollection).FindOne(ctx, bson.D{},
+		options.FindOne().SetSort(bson.D{{"changedAt", 1}}),
+	).Decode(&first)
+	switch {
+	case goErrors.Is(err, mongo.ErrNoDocuments):
+	case err != nil:
+		return fmt.Errorf("seedPriceHistory: %w", err)
+	default:
+		candidates = bson.D{{"$or", bson.A{
+			bson.D{{"lastModified", bson.D{{"$lt", first.ChangedAt}}}},
+			bson.D{{"lastModified", bson.D{{"$exists", false}}}},
+		}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{"$match", candidates}},
+		{{"$lookup", bson.D{
+			{"from", priceHistoryCollection},
+			{"let", bson.D{{"productId", "$_id"}}},
+			{"pipeline", mongo.Pipeline{
+				{{"$match", bson.D{{"$expr", bson.D{{"$eq", bson.A{"$productId", "$$productId"}}}}}}},
+				{{"$limit", 1}},
+				{{"$project", bson.D{{"_id", 1}}}},
+			}},
+			{"as", "history"},
+		}}},
+		{{"$match", bson.D{{"history", bson.D{{"$size", 0}}}}}},
+		{{"$project", bson.D{
+			{"event", bson.D{{"$literal", string(PriceEventPrice)}}},
+			{"productId", "$_id"},
+			{"name", 1},
+			{"newPrice", "$price"},
+			{"currency", 1},
+			{"externalId", 1},
+			{"changedAt", bson.D{{"$ifNull", bson.A{"$lastModified", bson.D{{"$toDate", "$_id"}}}}}},
+			{"source", 1},
+		}}},
+		{{"$merge", bson.D{
+			{"into", priceHistoryCollection},
+			{"on", "_id"},
+			{"whenMatched", "keepExisting"},
+			{"whenNotMatched", "insert"},
+		}}},
+	}
+
+	curs, err := db.Collection(productsCollection).Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return fmt.Errorf("seedPriceHistory: %w", err)
+	}
+	if err := curs.Close(ctx); err != nil {
+		return fmt.Errorf("seedPriceHistory: %w", err)
+	}
+
+	return nil
+}
+
+// findProductsAsOf rebuilds products from the latest price changes made before asOf,
+// then filters, sorts and pages them the same way as live products.
+func (s *mongodb) findProductsAsOf(ctx context.Context, asOf time.Time, opts ...option) ([]Product, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(priceHistoryCollection)
+
+	filter, mongoOpts, err := mongoFindFilterOpts(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("findProductsAsOf: %w", err)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{"$match", bson.D{{"changedAt", bson.D{{"$lte", asOf}}}}}},
+		// $last takes the latest change, ids alone are not ordered by time across instances
+		{{"$sort", bson.D{{"changedAt", 1}, {"_id", 1}}}},
+		{{"$addFields", bson.D{
+			// records made before archiving was introduced have no event
+			{"isPrice", bson.D{{"$eq", bson.A{bson.D{{"$ifNull", bson.A{"$event", string(PriceEventPrice)}}}, string(PriceEventPrice)}}}},
+			{"isArchived", bson.D{{"$eq", bson.A{"$event", string(PriceEventArchived)}}}},
+		}}},
+		{{"$group", bson.D{
+			{"_id", "$productId"},
+			{"name", bson.D{{"$last", "$name"}}},
+			{"price", bson.D{{"$last", "$newPrice"}}},
+			{"priceUpdateCount", bson.D{{"$sum", bson.D{{"$cond", bson.A{"$isPrice", 1, 0}}}}}},
+			// $max skips nulls, so archiving does not count as modification
+			{"lastModified", bson.D{{"$max", bson.D{{"$cond", bson.A{"$isPrice", "$changedAt", nil}}}}}},
+			{"externalId", bson.D{{"$last", "$externalId"}}},
+			{"currency", bson.D{{"$last", "$currency"}}},
+			{"source", bson.D{{"$last", "$source"}}},
+			{"archived", bson.D{{"$last", "$isArchived"}}},
+			{"archivedAt", bson.D{{"$last", bson.D{{"$cond", bson.A{"$isArchived", "$changedAt", nil}}}}}},
+		}}},
+		{{"$match", filter}},
+	}
+	if mongoOpts.Sort != nil {
+		pipeline = append(pipeline, bson.D{{"$sort", mongoOpts.Sort}})
+	}
+	if mongoOpts.Limit != nil {
+		pipeline = append(pipeline, bson.D{{"$limit", *mongoOpts.Limit}})
+	}
+
+	curs, err := coll.Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, fmt.Errorf("findProductsAsOf: %w", err)
+	}
+	defer curs.Close(ctx)
+
+	var pp []Product
+	for curs.Next(ctx) {
+		var p mongoProduct
+		if err := curs.Decode(&p); err != nil {
+			return pp, fmt.Errorf("findProductsAsOf: %w", err)
+		}
+
+		product, err := p.toProduct()
+		if err != nil {
+			return pp, fmt.Errorf("findProductsAsOf: %w", err)
+		}
+		pp = append(pp, product)
+	}
+	if err := curs.Err(); err != nil {
+		return pp, fmt.Errorf("findProductsAsOf: %w", err)
+	}
+
+	return pp, nil
+}
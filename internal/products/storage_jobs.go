@@ -0,0 +1,438 @@
+package products
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+)
+
+type mongoRowError struct {
+	Line   uint32   `bson:"line"`
+	Record []string `bson:"record"`
+	Reason string   `bson:"reason"`
+	Entry  string   `bson:"entry,omitempty"`
+}
+
+// mongoFetchJobError is a rejected row kept in full, unlike capped report errors.
+type mongoFetchJobError struct {
+	JobID         primitive.ObjectID `bson:"jobId"`
+	mongoRowError `bson:",inline"`
+}
+
+type mongoIngestionReport struct {
+	Parsed    uint32          `bson:"parsed"`
+	Written   uint32          `bson:"written"`
+	Inserted  uint32          `bson:"inserted"`
+	Repriced  uint32          `bson:"repriced"`
+	Unchanged uint32          `bson:"unchanged"`
+	Rejected  uint32          `bson:"rejected"`
+	Errors    []mongoRowError `bson:"errors,omitempty"`
+	Archived  uint32          `bson:"archived,omitempty"`
+	Restored  uint32          `bson:"restored,omitempty"`
+	Skipped   string          `bson:"skipped,omitempty"`
+	Retries   uint32          `bson:"retries,omitempty"`
+	Breaker   BreakerState    `bson:"breaker,omitempty"`
+}
+
+type mongoFetchJob struct {
+	ID              primitive.ObjectID   `bson:"_id,omitempty"`
+	URL             string               `bson:"url"`
+	Source          string               `bson:"source,omitempty"`
+	State           string               `bson:"state"`
+	Instance        string               `bson:"instance,omitempty"`
+	CancelRequested bool                 `bson:"cancelRequested"`
+	CreatedAt       time.Time            `bson:"createdAt"`
+	StartedAt       time.Time            `bson:"startedAt,omitempty"`
+	FinishedAt      time.Time            `bson:"finishedAt,omitempty"`
+	HeartbeatAt     time.Time            `bson:"heartbeatAt,omitempty"`
+	Report          mongoIngestionReport `bson:"report"`
+	Error           string               `bson:"error,omitempty"`
+}
+
+func newMongoIngestionReport(r IngestionReport) mongoIngestionReport {
+	mr := mongoIngestionReport{
+		Parsed:    r.Parsed,
+		Written:   r.Written,
+		Inserted:  r.Inserted,
+		Repriced:  r.Repriced,
+		Unchanged: r.Unchanged,
+		Rejected:  r.Rejected,
+		Archived:  r.Archived,
+		Restored:  r.Restored,
+		Skipped:   r.Skipped,
+		Retries:   r.Retries,
+		Breaker:   r.Breaker,
+	}
+	for _, e := range r.Errors {
+		mr.Errors = append(mr.Errors, mongoRowError(e))
+	}
+	return mr
+}
+
+func (r mongoIngestionReport) toIngestionReport() IngestionReport {
+	report := IngestionReport{
+		Parsed:    r.Parsed,
+		Written:   r.Written,
+		Inserted:  r.Inserted,
+		Repriced:  r.Repriced,
+		Unchanged: r.Unchanged,
+		Rejected:  r.Rejected,
+		Archived:  r.Archived,
+		Restored:  r.Restored,
+		Skipped:   r.Skipped,
+		Retries:   r.Retries,
+		Breaker:   r.Breaker,
+	}
+	for _, e := range r.Errors {
+		report.Errors = append(report.Errors, RowError(e))
+	}
+	return report
+}
+
+func (j mongoFetchJob) toFetchJob() FetchJob {
+	return FetchJob{
+		ID:              j.ID.Hex(),
+		URL:             j.URL,
+		Source:          j.Source,
+		State:           FetchJobState(j.State),
+		Instance:        j.Instance,
+		CancelRequested: j.CancelRequested,
+		CreatedAt:       j.CreatedAt,
+		StartedAt:       j.StartedAt,
+		FinishedAt:      j.FinishedAt,
+		HeartbeatAt:     j.HeartbeatAt,
+		Report:          j.Report.toIngestionReport(),
+		Error:           j.Error,
+	}
+}
+
+func fetchJobID(id string) (primitive.ObjectID, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return oid, errors.NewErrInvalidInput(fmt.Errorf("fetchJobID: %s: %w", id, err))
+	}
+	return oid, nil
+}
+
+func unfinishedFetchJobStates() bson.A {
+	return bson.A{string(FetchJobStatePending), string(FetchJobStateRunning)}
+}
+
+func (s *mongodb) CreateFetchJob(ctx context.Context, job FetchJob) (FetchJob, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(fetchJobsCollection)
+
+	mj := mongoFetchJob{
+		URL:       job.URL,
+		Source:    job.Source,
+		State:     string(FetchJobStatePending),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	res, err := coll.InsertOne(ctx, mj)
+	if err != nil {
+		return FetchJob{}, fmt.Errorf("CreateFetchJob: %w", err)
+	}
+	mj.ID = res.InsertedID.(primitive.ObjectID)
+
+	return mj.toFetchJob(), nil
+}
+
+// DeleteFetchJob removes pending job which is never going to run.
+func (s *mongodb) DeleteFetchJob(ctx context.Context, id string) error {
+	coll := s.cli.Database(s.cfg.Database).Collection(fetchJobsCollection)
+
+	oid, err := fetchJobID(id)
+	if err != nil {
+		return fmt.Errorf("DeleteFetchJob: %w", err)
+	}
+
+	_, err = coll.DeleteOne(ctx, bson.D{
+		{"_id", oid},
+		{"state", string(FetchJobStatePending)},
+	})
+	if err != nil {
+		return fmt.Errorf("DeleteFetchJob: %w", err)
+	}
+
+	return nil
+}
+
+func (s *mongodb) StartFetchJob(ctx context.Context, id, instance string) (bool, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(fetchJobsCollection)
+
+	oid, err := fetchJobID(id)
+	if err != nil {
+		return false, fmt.Errorf("StartFetchJob: %w", err)
+	}
+
+	now := time.Now().UTC()
+
+	res, err := coll.UpdateOne(ctx,
+		bson.D{
+			{"_id", oid},
+			{"state", string(FetchJobStatePending)},
+			{"cancelRequested", false},
+		},
+		bson.D{{"$set", bson.D{
+			{"state", string(FetchJobStateRunning)},
+			{"instance", instance},
+			{"startedAt", now},
+			{"heartbeatAt", now},
+		}}},
+	)
+	if err != nil {
+		return false, fmt.Errorf("StartFetchJob: %w", err)
+	}
+
+	return res.ModifiedCount == 1, nil
+}
+
+func (s *mongodb) HeartbeatFetchJob(ctx context.Context, id string, progress IngestionReport) (cancelRequested bool, err error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(fetchJobsCollection)
+
+	oid, err := fetchJobID(id)
+	if err != nil {
+		return false, fmt.Errorf("HeartbeatFetchJob: %w", err)
+	}
+
+	var mj mongoFetchJob
+	err = coll.FindOneAndUpdate(ctx,
+		bson.D{
+			{"_id", oid},
+			// pending while queued for a fetch worker
+			{"state", bson.D{{"$in", unfinishedFetchJobStates()}}},
+		},
+		bson.D{{"$set", bson.D{
+			{"heartbeatAt", time.Now().UTC()},
+			{"report", newMongoIngestionReport(progress)},
+		}}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&mj)
+	if goErrors.Is(err, mongo.ErrNoDocuments) {
+		return false, errors.NewErrNotFound(fmt.Errorf("HeartbeatFetchJob: unfinished fetch job %s: %w", id, err))
+	}
+	if err != nil {
+		return false, fmt.Errorf("HeartbeatFetchJob: %w", err)
+	}
+
+	return mj.CancelRequested, nil
+}
+
+func (s *mongodb) FinishFetchJob(ctx context.Context, job FetchJob) error {
+	coll := s.cli.Database(s.cfg.Database).Collection(fetchJobsCollection)
+
+	oid, err := fetchJobID(job.ID)
+	if err != nil {
+		return fmt.Errorf("FinishFetchJob: %w", err)
+	}
+
+	_, err = coll.UpdateOne(ctx,
+		bson.D{{"_id", oid}},
+		bson.D{{"$set", bson.D{
+			{"state", string(job.State)},
+			{"finishedAt", time.Now().UTC()},
+			{"report", newMongoIngestionReport(job.Report)},
+			{"error", job.Error},
+		}}},
+	)
+	if err != nil {
+		return fmt.Errorf("FinishFetchJob: %w", err)
+	}
+
+	return nil
+}
+
+func (s *mongodb) FailStaleFetchJobs(ctx context.Context, seenBefore time.Time) (int64, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(fetchJobsCollection)
+
+	res, err := coll.UpdateMany(ctx,
+		bson.D{
+			{"state", bson.D{{"$in", unfinishedFetchJobStates()}}},
+			// jobs not heartbeating yet
+			{"$or", bson.A{
+				bson.D{{"heartbeatAt", bson.D{{"$lt", seenBefore}}}},
+				bson.D{
+					{"heartbeatAt", bson.D{{"$exists", false}}},
+					{"createdAt", bson.D{{"$lt", seenBefore}}},
+				},
+			}},
+		},
+		bson.D{{"$set", bson.D{
+			{"state", string(FetchJobStateFailed)},
+			{"finishedAt", time.Now().UTC()},
+			{"error", fmt.Sprintf("abandoned: no heartbeat since %s, the instance running the job is gone", seenBefore.UTC().Format(time.RFC3339))},
+		}}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("FailStaleFetchJobs: %w", err)
+	}
+
+	return res.ModifiedCount, nil
+}
+
+func (s *mongodb) FindFetchJob(ctx context.Context, id string) (FetchJob, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(fetchJobsCollection)
+
+	oid, err := fetchJobID(id)
+	if err != nil {
+		return FetchJob{}, fmt.Errorf("FindFetchJob: %w", err)
+	}
+
+	var mj mongoFetchJob
+	err = coll.FindOne(ctx, bson.D{{"_id", oid}}).Decode(&mj)
+	if goErrors.Is(err, mongo.ErrNoDocuments) {
+		return FetchJob{}, errors.NewErrNotFound(fmt.Errorf("FindFetchJob: fetch job %s: %w", id, err))
+	}
+	if err != nil {
+		return FetchJob{}, fmt.Errorf("FindFetchJob: %w", err)
+	}
+
+	return mj.toFetchJob(), nil
+}
+
+func (s *mongodb) FindFetchJobs(ctx context.Context, filter FetchJobsFilter) ([]FetchJob, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(fetchJobsCollection)
+
+	mongoFilter := bson.D{}
+	if filter.LastID != "" {
+		lastID, err := fetchJobID(filter.LastID)
+		if err != nil {
+			return nil, fmt.Errorf("FindFetchJobs: %w", err)
+		}
+		mongoFilter = append(mongoFilter, bson.E{"_id", bson.D{{"$lt", lastID}}})
+	}
+	if len(filter.States) > 0 {
+		states := make(bson.A, len(filter.States))
+		for i, state := range filter.States {
+			states[i] = string(state)
+		}
+		mongoFilter = append(mongoFilter, bson.E{"state", bson.D{{"$in", states}}})
+	}
+
+	mongoOpts := options.Find().SetSort(bson.D{{"_id", -1}})
+	if filter.Limit > 0 {
+		mongoOpts.SetLimit(int64(filter.Limit))
+	}
+
+	curs, err := coll.Find(ctx, mongoFilter, mongoOpts)
+	if err != nil {
+		return nil, fmt.Errorf("FindFetchJobs: %w", err)
+	}
+	defer curs.Close(ctx)
+
+	var jj []FetchJob
+	for curs.Next(ctx) {
+		var mj mongoFetchJob
+		if err := curs.Decode(&mj); err != nil {
+			return jj, fmt.Errorf("FindFetchJobs: %w", err)
+		}
+		jj = append(jj, mj.toFetchJob())
+	}
+	if err := curs.Err(); err != nil {
+		return jj, fmt.Errorf("FindFetchJobs: %w", err)
+	}
+
+	return jj, nil
+}
+
+// CancelFetchJob only flags the job, running instance notices the flag on its next heartbeat.
+// Finished jobs are returned as is.
+func (s *mongodb) CancelFetchJob(ctx context.Context, id string) (FetchJob, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(fetchJobsCollection)
+
+	oid, err := fetchJobID(id)
+	if err != nil {
+		return FetchJob{}, fmt.Errorf("CancelFetchJob: %w", err)
+	}
+
+	var mj mongoFetchJob
+	err = coll.FindOneAndUpdate(ctx,
+		bson.D{
+			{"_id", oid},
+			{"state", bson.D{{"$in", unfinishedFetchJobStates()}}},
+		},
+		bson.D{{"$set", bson.D{{"cancelRequested", true}}}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&mj)
+	if goErrors.Is(err, mongo.ErrNoDocuments) {
+		job, err := s.FindFetchJob(ctx, id)
+		if err != nil {
+			return FetchJob{}, fmt.Errorf("CancelFetchJob: %w", err)
+		}
+		return job, nil
+	}
+	if err != nil {
+		return FetchJob{}, fmt.Errorf("CancelFetchJob: %w", err)
+	}
+
+	return mj.toFetchJob(), nil
+}
+
+func (s *mongodb) InsertFetchJobErrors(ctx context.Context, id string, ee []RowError) error {
+	coll := s.cli.Database(s.cfg.Database).Collection(fetchJobErrorsCollection)
+
+	oid, err := fetchJobID(id)
+	if err != nil {
+		return fmt.Errorf("InsertFetchJobErrors: %w", err)
+	}
+
+	if len(ee) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(ee))
+	for i, e := range ee {
+		docs[i] = mongoFetchJobError{
+			JobID:         oid,
+			mongoRowError: mongoRowError(e),
+		}
+	}
+
+	if _, err := coll.InsertMany(ctx, docs, options.InsertMany().SetOrdered(false)); err != nil {
+		return fmt.Errorf("InsertFetchJobErrors: %w", err)
+	}
+
+	return nil
+}
+
+func (s *mongodb) FindFetchJobErrors(ctx context.Context, id string, fn func(e RowError) error) error {
+	coll := s.cli.Database(s.cfg.Database).Collection(fetchJobErrorsCollection)
+
+	oid, err := fetchJobID(id)
+	if err != nil {
+		return fmt.Errorf("FindFetchJobErrors: %w", err)
+	}
+
+	curs, err := coll.Find(ctx,
+		bson.D{{"jobId", oid}},
+		options.Find().SetSort(bson.D{{"line", 1}}),
+	)
+	if err != nil {
+		return fmt.Errorf("FindFetchJobErrors: %w", err)
+	}
+	defer curs.Close(ctx)
+
+	for curs.Next(ctx) {
+		var me mongoFetchJobError
+		if err := curs.Decode(&me); err != nil {
+			return fmt.Errorf("FindFetchJobErrors: %w", err)
+		}
+
+		if err := fn(RowError(me.mongoRowError)); err != nil {
+			return fmt.Errorf("FindFetchJobErrors: %w", err)
+		}
+	}
+	if err := curs.Err(); err != nil {
+		return fmt.Errorf("FindFetchJobErrors: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package products
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoLease struct {
+	Name      string    `bson:"_id"`
+	Holder    string    `bson:"holder"`
+	ExpiresAt time.Time `bson:"expiresAt"`
+}
+
+// AcquireLease takes the named lease or prolongs it if the holder has it already,
+// the current holder is returned either way.
+// Expired lease is taken over by anyone, so instances clocks are expected to be far closer than ttl.
+func (s *mongodb) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (string, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(leasesCollection)
+
+	for {
+		now := time.Now().UTC()
+
+		_, err := coll.UpdateOne(ctx,
+			bson.D{
+				{"_id", name},
+				{"$or", bson.A{
+					bson.D{{"holder", holder}},
+					bson.D{{"expiresAt", bson.D{{"$lte", now}}}},
+				}},
+			},
+			bson.D{{"$set", bson.D{
+				{"holder", holder},
+				{"expiresAt", now.Add(ttl)},
+			}}},
+			options.Update().SetUpsert(true),
+		)
+		if err == nil {
+			return holder, nil
+		}
+		// lease is held by someone else, so the filter misses it and upsert hits _id
+		if !isDuplicateKey(err) {
+			return "", fmt.Errorf("AcquireLease: %w", err)
+		}
+
+		var ml mongoLease
+		err = coll.FindOne(ctx, bson.D{{"_id", name}}).Decode(&ml)
+		if err != nil && !goErrors.Is(err, mongo.ErrNoDocuments) {
+			return "", fmt.Errorf("AcquireLease: %w", err)
+		}
+		if err == nil && ml.ExpiresAt.After(now) {
+			return ml.Holder, nil
+		}
+
+		// released or expired in between, try again
+		if err := ctx.Err(); err != nil {
+			return "", fmt.Errorf("AcquireLease: %w", err)
+		}
+	}
+}
+
+// ReleaseLease lets others take the lease right away instead of waiting for it to expire.
+func (s *mongodb) ReleaseLease(ctx context.Context, name, holder string) error {
+	coll := s.cli.Database(s.cfg.Database).Collection(leasesCollection)
+
+	if _, err := coll.DeleteOne(ctx, bson.D{{"_id", name}, {"holder", holder}}); err != nil {
+		return fmt.Errorf("ReleaseLease: %w", err)
+	}
+
+	return nil
+}
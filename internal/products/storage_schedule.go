@@ -0,0 +1,66 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *mongodb) FindDueSources(ctx context.Context, now time.Time) ([]Source, error) {
+	ss, err := s.findSources(ctx,
+		bson.D{
+			{"enabled", true},
+			{"nextRunAt", bson.D{{"$lte", now}}},
+		},
+		options.Find().SetSort(bson.D{{"nextRunAt", 1}}),
+	)
+	if err != nil {
+		return ss, fmt.Errorf("FindDueSources: %w", err)
+	}
+
+	return ss, nil
+}
+
+// ClaimSourceRun moves the source to its next run if it is still due at the given time.
+// Only one of the callers racing for the same run gets true.
+func (s *mongodb) ClaimSourceRun(ctx context.Context, name string, due, next time.Time) (bool, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(sourcesCollection)
+
+	set := bson.D{{"lastRunAt", time.Now().UTC()}}
+	unset := bson.D{}
+	if next.IsZero() {
+		unset = append(unset, bson.E{"nextRunAt", ""})
+	} else {
+		set = append(set, bson.E{"nextRunAt", next})
+	}
+	update := bson.D{{"$set", set}}
+	if len(unset) > 0 {
+		update = append(update, bson.E{"$unset", unset})
+	}
+
+	res, err := coll.UpdateOne(ctx, bson.D{{"name", name}, {"nextRunAt", due}}, update)
+	if err != nil {
+		return false, fmt.Errorf("ClaimSourceRun: %w", err)
+	}
+
+	return res.ModifiedCount == 1, nil
+}
+
+// FinishSourceRun records the outcome of the claimed run, empty jobID keeps the last job.
+func (s *mongodb) FinishSourceRun(ctx context.Context, name, jobID, runErr string) error {
+	coll := s.cli.Database(s.cfg.Database).Collection(sourcesCollection)
+
+	set := bson.D{{"lastRunError", runErr}}
+	if jobID != "" {
+		set = append(set, bson.E{"lastJobId", jobID})
+	}
+
+	if _, err := coll.UpdateOne(ctx, bson.D{{"name", name}}, bson.D{{"$set", set}}); err != nil {
+		return fmt.Errorf("FinishSourceRun: %w", err)
+	}
+
+	return nil
+}
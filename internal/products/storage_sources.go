@@ -0,0 +1,444 @@
+package products
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+)
+
+// mongoPair keeps map entries as an array, keys like header or column names may contain dots and dollars.
+type mongoPair struct {
+	Key   string `bson:"key"`
+	Value string `bson:"value"`
+}
+
+func newMongoPairs(m map[string]string) []mongoPair {
+	pairs := make([]mongoPair, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, mongoPair{Key: k, Value: v})
+	}
+	return pairs
+}
+
+func pairsToMap(pairs []mongoPair) map[string]string {
+	if len(pairs) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(pairs))
+	for _, p := range pairs {
+		m[p.Key] = p.Value
+	}
+	return m
+}
+
+type mongoFeedSchema struct {
+	Delimiter        rune        `bson:"delimiter,omitempty"`
+	Quotes           QuoteMode   `bson:"quotes"`
+	Header           HeaderMode  `bson:"header"`
+	Columns          []mongoPair `bson:"columns,omitempty"`
+	RecordPath       string      `bson:"recordPath,omitempty"`
+	Charset          string      `bson:"charset,omitempty"`
+	DecimalSeparator rune        `bson:"decimalSeparator,omitempty"`
+	GroupSeparator   rune        `bson:"groupSeparator,omitempty"`
+}
+
+type mongoErrorPolicy struct {
+	Skip               bool    `bson:"skip"`
+	MaxRejected        uint32  `bson:"maxRejected"`
+	MaxRejectedPercent float64 `bson:"maxRejectedPercent"`
+}
+
+type mongoSyncPolicy struct {
+	Mode          SyncMode `bson:"mode"`
+	MissedFetches uint32   `bson:"missedFetches"`
+}
+
+type mongoArchiveOptions struct {
+	Pattern string `bson:"pattern,omitempty"`
+	All     bool   `bson:"all"`
+}
+
+// mongoSecret keeps inline secret encrypted.
+type mongoSecret struct {
+	Sealed []byte `bson:"sealed,omitempty"`
+	File   string `bson:"file,omitempty"`
+}
+
+type mongoSourceAuth struct {
+	Username   string            `bson:"username,omitempty"`
+	Password   mongoSecret       `bson:"password"`
+	Token      mongoSecret       `bson:"token"`
+	Headers    []mongoSecretPair `bson:"headers,omitempty"`
+	ClientCert mongoSecret       `bson:"clientCert"`
+	ClientKey  mongoSecret       `bson:"clientKey"`
+	CA         mongoSecret       `bson:"ca"`
+}
+
+type mongoSecretPair struct {
+	Key   string      `bson:"key"`
+	Value mongoSecret `bson:"value"`
+}
+
+func (b *secretBox) newMongoSecret(s Secret) (mongoSecret, error) {
+	if s.Value == "" {
+		return mongoSecret{File: s.File}, nil
+	}
+
+	sealed, err := b.seal(s.Value)
+	if err != nil {
+		return mongoSecret{}, errors.NewErrInvalidInput(fmt.Errorf("newMongoSecret: %w", err))
+	}
+
+	return mongoSecret{Sealed: sealed}, nil
+}
+
+func (b *secretBox) toSecret(ms mongoSecret) (Secret, error) {
+	if len(ms.Sealed) == 0 {
+		return Secret{File: ms.File}, nil
+	}
+
+	value, err := b.open(ms.Sealed)
+	if err != nil {
+		return Secret{}, fmt.Errorf("toSecret: %w", err)
+	}
+
+	return Secret{Value: value}, nil
+}
+
+func (b *secretBox) newMongoSourceAuth(a SourceAuth) (mongoSourceAuth, error) {
+	ma := mongoSourceAuth{Username: a.Username}
+
+	var err error
+	for _, s := range []struct {
+		from Secret
+		to   *mongoSecret
+	}{
+		{a.Password, &ma.Password},
+		{a.Token, &ma.Token},
+		{a.ClientCert, &ma.ClientCert},
+		{a.ClientKey, &ma.ClientKey},
+		{a.CA, &ma.CA},
+	} {
+		if *s.to, err = b.newMongoSecret(s.from); err != nil {
+			return ma, fmt.Errorf("newMongoSourceAuth: %w", err)
+		}
+	}
+
+	for name, secret := range a.Headers {
+		ms, err := b.newMongoSecret(secret)
+		if err != nil {
+			return ma, fmt.Errorf("newMongoSourceAuth: %w", err)
+		}
+		ma.Headers = append(ma.Headers, mongoSecretPair{Key: name, Value: ms})
+	}
+
+	return ma, nil
+}
+
+func (b *secretBox) toSourceAuth(ma mongoSourceAuth) (SourceAuth, error) {
+	a := SourceAuth{Username: ma.Username}
+
+	var err error
+	for _, s := range []struct {
+		from mongoSecret
+		to   *Secret
+	}{
+		{ma.Password, &a.Password},
+		{ma.Token, &a.Token},
+		{ma.ClientCert, &a.ClientCert},
+		{ma.ClientKey, &a.ClientKey},
+		{ma.CA, &a.CA},
+	} {
+		if *s.to, err = b.toSecret(s.from); err != nil {
+			return a, fmt.Errorf("toSourceAuth: %w", err)
+		}
+	}
+
+	if len(ma.Headers) > 0 {
+		a.Headers = make(map[string]Secret, len(ma.Headers))
+		for _, p := range ma.Headers {
+			if a.Headers[p.Key], err = b.toSecret(p.Value); err != nil {
+				return a, fmt.Errorf("toSourceAuth: %w", err)
+			}
+		}
+	}
+
+	return a, nil
+}
+
+type mongoSource struct {
+	Name        string              `bson:"name"`
+	URL         string              `bson:"url"`
+	Format      string              `bson:"format,omitempty"`
+	Schema      mongoFeedSchema     `bson:"schema"`
+	Archive     mongoArchiveOptions `bson:"archive"`
+	Headers     []mongoPair         `bson:"headers,omitempty"`
+	Auth        mongoSourceAuth     `bson:"auth"`
+	ErrorPolicy mongoErrorPolicy    `bson:"errorPolicy"`
+	Sync        mongoSyncPolicy     `bson:"sync"`
+	Enabled     bool                `bson:"enabled"`
+	Schedule    string              `bson:"schedule,omitempty"`
+	CreatedAt   time.Time           `bson:"createdAt"`
+	UpdatedAt   time.Time           `bson:"updatedAt"`
+
+	NextRunAt    time.Time `bson:"nextRunAt,omitempty"`
+	LastRunAt    time.Time `bson:"lastRunAt,omitempty"`
+	LastJobID    string    `bson:"lastJobId,omitempty"`
+	LastRunError string    `bson:"lastRunError,omitempty"`
+}
+
+func (s *mongodb) newMongoSource(src Source) (mongoSource, error) {
+	auth, err := s.secrets.newMongoSourceAuth(src.Auth)
+	if err != nil {
+		return mongoSource{}, fmt.Errorf("newMongoSource: %w", err)
+	}
+
+	return mongoSource{
+		Name:   src.Name,
+		URL:    src.URL,
+		Format: string(src.Format),
+		Schema: mongoFeedSchema{
+			Delimiter:        src.Schema.Delimiter,
+			Quotes:           src.Schema.Quotes,
+			Header:           src.Schema.Header,
+			Columns:          newMongoPairs(src.Schema.Columns),
+			RecordPath:       src.Schema.RecordPath,
+			Charset:          src.Schema.Charset,
+			DecimalSeparator: src.Schema.Price.DecimalSeparator,
+			GroupSeparator:   src.Schema.Price.GroupSeparator,
+		},
+		Archive:     mongoArchiveOptions(src.Archive),
+		Headers:     newMongoPairs(src.Headers),
+		Auth:        auth,
+		ErrorPolicy: mongoErrorPolicy(src.ErrorPolicy),
+		Sync:        mongoSyncPolicy(src.Sync),
+		Enabled:     src.Enabled,
+		Schedule:    src.Schedule,
+		CreatedAt:   src.CreatedAt,
+		UpdatedAt:   src.UpdatedAt,
+
+		NextRunAt:    src.NextRunAt,
+		LastRunAt:    src.LastRunAt,
+		LastJobID:    src.LastJobID,
+		LastRunError: src.LastRunError,
+	}, nil
+}
+
+func (s *mongodb) toSource(ms mongoSource) (Source, error) {
+	auth, err := s.secrets.toSourceAuth(ms.Auth)
+	if err != nil {
+		return Source{}, fmt.Errorf("toSource: source %s: %w", ms.Name, err)
+	}
+
+	return Source{
+		Name:   ms.Name,
+		URL:    ms.URL,
+		Format: FeedFormat(ms.Format),
+		Schema: FeedSchema{
+			Delimiter:  ms.Schema.Delimiter,
+			Quotes:     ms.Schema.Quotes,
+			Header:     ms.Schema.Header,
+			Columns:    pairsToMap(ms.Schema.Columns),
+			RecordPath: ms.Schema.RecordPath,
+			Charset:    ms.Schema.Charset,
+			Price: PriceFormat{
+				DecimalSeparator: ms.Schema.DecimalSeparator,
+				GroupSeparator:   ms.Schema.GroupSeparator,
+			},
+		},
+		Archive:     ArchiveOptions(ms.Archive),
+		Headers:     pairsToMap(ms.Headers),
+		Auth:        auth,
+		ErrorPolicy: ErrorPolicy(ms.ErrorPolicy),
+		Sync:        SyncPolicy(ms.Sync),
+		Enabled:     ms.Enabled,
+		Schedule:    ms.Schedule,
+		CreatedAt:   ms.CreatedAt,
+		UpdatedAt:   ms.UpdatedAt,
+
+		NextRunAt:    ms.NextRunAt,
+		LastRunAt:    ms.LastRunAt,
+		LastJobID:    ms.LastJobID,
+		LastRunError: ms.LastRunError,
+	}, nil
+}
+
+func isDuplicateKey(err error) bool {
+	var we mongo.WriteException
+	if !goErrors.As(err, &we) {
+		return false
+	}
+	for _, e := range we.WriteErrors {
+		if e.Code == errCodeDuplicateKey {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *mongodb) CreateSource(ctx context.Context, src Source) (Source, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(sourcesCollection)
+
+	now := time.Now().UTC()
+	src.CreatedAt, src.UpdatedAt = now, now
+
+	ms, err := s.newMongoSource(src)
+	if err != nil {
+		return Source{}, fmt.Errorf("CreateSource: %w", err)
+	}
+
+	_, err = coll.InsertOne(ctx, ms)
+	if isDuplicateKey(err) {
+		return Source{}, errors.NewErrAlreadyExists(fmt.Errorf("CreateSource: source %s: %w", src.Name, err))
+	}
+	if err != nil {
+		return Source{}, fmt.Errorf("CreateSource: %w", err)
+	}
+
+	return src, nil
+}
+
+// UpdateSource replaces the whole definition, creation time and last scheduled run are kept.
+func (s *mongodb) UpdateSource(ctx context.Context, src Source) (Source, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(sourcesCollection)
+
+	ms, err := s.newMongoSource(src)
+	if err != nil {
+		return Source{}, fmt.Errorf("UpdateSource: %w", err)
+	}
+	ms.UpdatedAt = time.Now().UTC()
+
+	set := bson.D{
+		{"url", ms.URL},
+		{"format", ms.Format},
+		{"schema", ms.Schema},
+		{"archive", ms.Archive},
+		{"headers", ms.Headers},
+		{"auth", ms.Auth},
+		{"errorPolicy", ms.ErrorPolicy},
+		{"sync", ms.Sync},
+		{"enabled", ms.Enabled},
+		{"schedule", ms.Schedule},
+		{"updatedAt", ms.UpdatedAt},
+	}
+	unset := bson.D{}
+	// unscheduled source must not look due
+	if ms.NextRunAt.IsZero() {
+		unset = append(unset, bson.E{"nextRunAt", ""})
+	} else {
+		set = append(set, bson.E{"nextRunAt", ms.NextRunAt})
+	}
+	update := bson.D{{"$set", set}}
+	if len(unset) > 0 {
+		update = append(update, bson.E{"$unset", unset})
+	}
+
+	var updated mongoSource
+	err = coll.FindOneAndUpdate(ctx,
+		bson.D{{"name", src.Name}},
+		update,
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if goErrors.Is(err, mongo.ErrNoDocuments) {
+		return Source{}, errors.NewErrNotFound(fmt.Errorf("UpdateSource: source %s: %w", src.Name, err))
+	}
+	if err != nil {
+		return Source{}, fmt.Errorf("UpdateSource: %w", err)
+	}
+
+	src, err = s.toSource(updated)
+	if err != nil {
+		return Source{}, fmt.Errorf("UpdateSource: %w", err)
+	}
+
+	return src, nil
+}
+
+func (s *mongodb) FindSource(ctx context.Context, name string) (Source, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(sourcesCollection)
+
+	var ms mongoSource
+	err := coll.FindOne(ctx, bson.D{{"name", name}}).Decode(&ms)
+	if goErrors.Is(err, mongo.ErrNoDocuments) {
+		return Source{}, errors.NewErrNotFound(fmt.Errorf("FindSource: source %s: %w", name, err))
+	}
+	if err != nil {
+		return Source{}, fmt.Errorf("FindSource: %w", err)
+	}
+
+	src, err := s.toSource(ms)
+	if err != nil {
+		return Source{}, fmt.Errorf("FindSource: %w", err)
+	}
+
+	return src, nil
+}
+
+func (s *mongodb) FindSources(ctx context.Context, filter SourcesFilter) ([]Source, error) {
+	mongoFilter := bson.D{}
+	if filter.LastName != "" {
+		mongoFilter = append(mongoFilter, bson.E{"name", bson.D{{"$gt", filter.LastName}}})
+	}
+
+	mongoOpts := options.Find().SetSort(bson.D{{"name", 1}})
+	if filter.Limit > 0 {
+		mongoOpts.SetLimit(int64(filter.Limit))
+	}
+
+	ss, err := s.findSources(ctx, mongoFilter, mongoOpts)
+	if err != nil {
+		return ss, fmt.Errorf("FindSources: %w", err)
+	}
+
+	return ss, nil
+}
+
+func (s *mongodb) findSources(ctx context.Context, filter bson.D, mongoOpts *options.FindOptions) ([]Source, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(sourcesCollection)
+
+	curs, err := coll.Find(ctx, filter, mongoOpts)
+	if err != nil {
+		return nil, fmt.Errorf("findSources: %w", err)
+	}
+	defer curs.Close(ctx)
+
+	var ss []Source
+	for curs.Next(ctx) {
+		var ms mongoSource
+		if err := curs.Decode(&ms); err != nil {
+			return ss, fmt.Errorf("findSources: %w", err)
+		}
+		src, err := s.toSource(ms)
+		if err != nil {
+			return ss, fmt.Errorf("findSources: %w", err)
+		}
+		ss = append(ss, src)
+	}
+	if err := curs.Err(); err != nil {
+		return ss, fmt.Errorf("findSources: %w", err)
+	}
+
+	return ss, nil
+}
+
+// DeleteSource keeps products and fetch jobs of the source.
+func (s *mongodb) DeleteSource(ctx context.Context, name string) error {
+	coll := s.cli.Database(s.cfg.Database).Collection(sourcesCollection)
+
+	res, err := coll.DeleteOne(ctx, bson.D{{"name", name}})
+	if err != nil {
+		return fmt.Errorf("DeleteSource: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return errors.NewErrNotFound(fmt.Errorf("DeleteSource: source %s not found", name))
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package products
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+)
+
+// markListed remembers the source and the job that listed products last, archived ones get restored.
+func (s *mongodb) markListed(ctx context.Context, names []string, now time.Time, origin PriceOrigin) (restored uint32, err error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(productsCollection)
+
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	archived, err := s.findProducts(ctx, bson.D{{"name", bson.D{{"$in", names}}}, {"archived", true}})
+	if err != nil {
+		return 0, fmt.Errorf("markListed: %w", err)
+	}
+
+	_, err = coll.UpdateMany(ctx, bson.D{{"name", bson.D{{"$in", names}}}}, bson.D{
+		{"$set", bson.D{{"source", origin.Source}, {"lastListedJobId", origin.JobID}, {"missedFetches", 0}}},
+		{"$unset", bson.D{{"archived", ""}, {"archivedAt", ""}}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("markListed: %w", err)
+	}
+
+	changes := make([]mongoPriceChange, len(archived))
+	for i, p := range archived {
+		changes[i] = p.priceChange(PriceEventRestored, p.ID, nil, now, origin)
+	}
+	if err := s.insertPriceChanges(ctx, changes); err != nil {
+		return 0, fmt.Errorf("markListed: %w", err)
+	}
+
+	return uint32(len(archived)), nil
+}
+
+func (s *mongodb) ArchiveMissingProducts(ctx context.Context, origin PriceOrigin, policy SyncPolicy) (archived uint32, err error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(productsCollection)
+
+	missing := bson.D{
+		{"source", origin.Source},
+		{"lastListedJobId", bson.D{{"$ne", origin.JobID}}},
+		{"archived", bson.D{{"$ne", true}}},
+	}
+
+	if _, err := coll.UpdateMany(ctx, missing, bson.D{{"$inc", bson.D{{"missedFetches", 1}}}}); err != nil {
+		return 0, fmt.Errorf("ArchiveMissingProducts: %w", err)
+	}
+
+	toArchive, err := s.findProducts(ctx, append(missing, bson.E{"missedFetches", bson.D{{"$gte", policy.missedFetches()}}}))
+	if err != nil {
+		return 0, fmt.Errorf("ArchiveMissingProducts: %w", err)
+	}
+	if len(toArchive) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now().UTC()
+
+	ids := make(bson.A, len(toArchive))
+	changes := make([]mongoPriceChange, len(toArchive))
+	for i, p := range toArchive {
+		ids[i] = p.ID
+		changes[i] = p.priceChange(PriceEventArchived, p.ID, nil, now, origin)
+	}
+
+	res, err := coll.UpdateMany(ctx, bson.D{{"_id", bson.D{{"$in", ids}}}, {"archived", bson.D{{"$ne", true}}}}, bson.D{
+		{"$set", bson.D{{"archived", true}, {"archivedAt", now}}},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("ArchiveMissingProducts: %w", err)
+	}
+
+	if err := s.insertPriceChanges(ctx, changes); err != nil {
+		return uint32(res.ModifiedCount), fmt.Errorf("ArchiveMissingProducts: %w", err)
+	}
+
+	return uint32(res.ModifiedCount), nil
+}
+
+func (s *mongodb) KeepListed(ctx context.Context, names []string, origin PriceOrigin) error {
+	coll := s.cli.Database(s.cfg.Database).Collection(productsCollection)
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	_, err := coll.UpdateMany(ctx,
+		bson.D{{"name", bson.D{{"$in", names}}}, {"source", origin.Source}},
+		bson.D{{"$set", bson.D{{"lastListedJobId", origin.JobID}}}},
+	)
+	if err != nil {
+		return fmt.Errorf("KeepListed: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreProduct returns products which are not archived as is.
+func (s *mongodb) RestoreProduct(ctx context.Context, id string) (Product, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(productsCollection)
+
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Product{}, errors.NewErrInvalidInput(fmt.Errorf("RestoreProduct: %s: %w", id, err))
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var mp mongoProduct
+	err = coll.FindOneAndUpdate(ctx, bson.D{{"_id", oid}, {"archived", true}}, bson.D{
+		{"$set", bson.D{{"missedFetches", 0}}},
+		{"$unset", bson.D{{"archived", ""}, {"archivedAt", ""}}},
+	}, opts).Decode(&mp)
+	if goErrors.Is(err, mongo.ErrNoDocuments) {
+		err = coll.FindOne(ctx, bson.D{{"_id", oid}}).Decode(&mp)
+		if goErrors.Is(err, mongo.ErrNoDocuments) {
+			return Product{}, errors.NewErrNotFound(fmt.Errorf("RestoreProduct: product %s not found", id))
+		}
+		if err != nil {
+			return Product{}, fmt.Errorf("RestoreProduct: %w", err)
+		}
+
+		p, err := mp.toProduct()
+		if err != nil {
+			return Product{}, fmt.Errorf("RestoreProduct: %w", err)
+		}
+		return p, nil
+	}
+	if err != nil {
+		return Product{}, fmt.Errorf("RestoreProduct: %w", err)
+	}
+
+	change := mp.priceChange(PriceEventRestored, mp.ID, nil, time.Now().UTC(), PriceOrigin{Source: mp.Source})
+	if err := s.insertPriceChanges(ctx, []mongoPriceChange{change}); err != nil {
+		return Product{}, fmt.Errorf("RestoreProduct: %w", err)
+	}
+
+	p, err := mp.toProduct()
+	if err != nil {
+		return Product{}, fmt.Errorf("RestoreProduct: %w", err)
+	}
+
+	return p, nil
+}
+
+func (s *mongodb) findProducts(ctx context.Context, filter bson.D) ([]mongoProduct, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(productsCollection)
+
+	curs, err := coll.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("findProducts: %w", err)
+	}
+	defer curs.Close(ctx)
+
+	var pp []mongoProduct
+	for curs.Next(ctx) {
+		var p mongoProduct
+		if err := curs.Decode(&p); err != nil {
+			return pp, fmt.Errorf("findProducts: %w", err)
+		}
+		pp = append(pp, p)
+	}
+	if err := curs.Err(); err != nil {
+		return pp, fmt.Errorf("findProducts: %w", err)
+	}
+
+	return pp, nil
+}
@@ -0,0 +1,70 @@
+package products
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type mongoFeedVersion struct {
+	Key          string    `bson:"_id"`
+	ETag         string    `bson:"etag,omitempty"`
+	LastModified string    `bson:"lastModified,omitempty"`
+	SHA256       string    `bson:"sha256"`
+	FetchedAt    time.Time `bson:"fetchedAt"`
+}
+
+// FindFeedVersion returns zero version for the feed never fetched in full.
+func (s *mongodb) FindFeedVersion(ctx context.Context, key string) (FeedVersion, error) {
+	coll := s.cli.Database(s.cfg.Database).Collection(feedVersionsCollection)
+
+	var mv mongoFeedVersion
+	err := coll.FindOne(ctx, bson.D{{"_id", key}}).Decode(&mv)
+	if goErrors.Is(err, mongo.ErrNoDocuments) {
+		return FeedVersion{}, nil
+	}
+	if err != nil {
+		return FeedVersion{}, fmt.Errorf("FindFeedVersion: %w", err)
+	}
+
+	return FeedVersion{
+		ETag:         mv.ETag,
+		LastModified: mv.LastModified,
+		SHA256:       mv.SHA256,
+	}, nil
+}
+
+func (s *mongodb) SaveFeedVersion(ctx context.Context, key string, v FeedVersion) error {
+	coll := s.cli.Database(s.cfg.Database).Collection(feedVersionsCollection)
+
+	mv := mongoFeedVersion{
+		Key:          key,
+		ETag:         v.ETag,
+		LastModified: v.LastModified,
+		SHA256:       v.SHA256,
+		FetchedAt:    time.Now().UTC(),
+	}
+
+	_, err := coll.ReplaceOne(ctx, bson.D{{"_id", key}}, mv, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("SaveFeedVersion: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFeedVersion makes the next fetch ingest the feed whatever it is.
+func (s *mongodb) DeleteFeedVersion(ctx context.Context, key string) error {
+	coll := s.cli.Database(s.cfg.Database).Collection(feedVersionsCollection)
+
+	if _, err := coll.DeleteOne(ctx, bson.D{{"_id", key}}); err != nil {
+		return fmt.Errorf("DeleteFeedVersion: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,363 @@
+package products
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync/atomic"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+type Compression string
+
+const (
+	CompressionNone Compression = ""
+	CompressionGzip Compression = "gzip"
+	CompressionZstd Compression = "zstd"
+	CompressionZip  Compression = "zip"
+)
+
+type ArchiveOptions struct {
+	// glob matched against entry path or base name, every entry if empty
+	Pattern string
+	// ingest every matching entry instead of the first one
+	All bool
+}
+
+func (o ArchiveOptions) Validate() error {
+	if _, err := path.Match(o.Pattern, ""); err != nil {
+		return fmt.Errorf("Validate: archive entry pattern %q: %w", o.Pattern, err)
+	}
+	return nil
+}
+
+func (o ArchiveOptions) match(name string) bool {
+	if o.Pattern == "" {
+		return true
+	}
+	if ok, _ := path.Match(o.Pattern, name); ok {
+		return true
+	}
+	ok, _ := path.Match(o.Pattern, path.Base(name))
+	return ok
+}
+
+// UnpackLimits guard against decompression bombs, zero means unlimited.
+type UnpackLimits struct {
+	// max bytes of decompressed feed, also max size of downloaded archive
+	MaxSize int64
+	// max decompressed to compressed size ratio
+	MaxRatio float64
+}
+
+// compression ratio is not checked for first bytes, decompressors read ahead
+const ratioCheckThreshold = 1024 * 1024
+
+var (
+	contentEncodingCompressions = map[string]Compression{
+		"gzip":   CompressionGzip,
+		"x-gzip": CompressionGzip,
+		"zstd":   CompressionZstd,
+	}
+
+	contentTypeCompressions = map[string]Compression{
+		"application/gzip":             CompressionGzip,
+		"application/x-gzip":           CompressionGzip,
+		"application/zstd":             CompressionZstd,
+		"application/zip":              CompressionZip,
+		"application/x-zip-compressed": CompressionZip,
+	}
+
+	extensionCompressions = map[string]Compression{
+		".gz":   CompressionGzip,
+		".gzip": CompressionGzip,
+		".zst":  CompressionZstd,
+		".zstd": CompressionZstd,
+		".zip":  CompressionZip,
+	}
+
+	magicCompressions = []struct {
+		magic       []byte
+		compression Compression
+	}{
+		{[]byte{0x1f, 0x8b}, CompressionGzip},
+		{[]byte{0x28, 0xb5, 0x2f, 0xfd}, CompressionZstd},
+		{[]byte("PK\x03\x04"), CompressionZip},
+	}
+)
+
+// feedMeta is what is known about the feed besides its content.
+type feedMeta struct {
+	// url, file path or archive entry name
+	Name            string
+	ContentType     string
+	ContentEncoding string
+}
+
+// detectCompression trusts content encoding, then content type, then name extension, then magic bytes.
+func detectCompression(meta feedMeta, br *bufio.Reader) (c Compression, compressedType bool, compressedExt bool) {
+	if c, ok := contentEncodingCompressions[strings.ToLower(meta.ContentEncoding)]; ok {
+		return c, false, false
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(meta.ContentType); err == nil {
+		if c, ok := contentTypeCompressions[mediaType]; ok {
+			return c, true, false
+		}
+	}
+
+	if c, ok := extensionCompressions[strings.ToLower(path.Ext(namePath(meta.Name)))]; ok {
+		return c, false, true
+	}
+
+	peeked, _ := br.Peek(4)
+	for _, m := range magicCompressions {
+		if bytes.HasPrefix(peeked, m.magic) {
+			return m.compression, false, false
+		}
+	}
+
+	return CompressionNone, false, false
+}
+
+func namePath(name string) string {
+	if u, err := url.Parse(name); err == nil {
+		return u.Path
+	}
+	return name
+}
+
+// trimExt drops compression extension, so the inner format is detected by the rest of the name.
+func trimExt(name string) string {
+	u, err := url.Parse(name)
+	if err != nil {
+		return strings.TrimSuffix(name, path.Ext(name))
+	}
+	u.Path = strings.TrimSuffix(u.Path, path.Ext(u.Path))
+	return u.String()
+}
+
+// decodeFeed unpacks compressed or archived feed on the fly and decodes it with the detected format.
+func decodeFeed(body io.Reader, meta feedMeta, limits UnpackLimits, opts *optsHolder, fn func(row FeedRow) error) error {
+	if err := unpackFeed(body, meta, limits, opts, true, fn); err != nil {
+		return fmt.Errorf("decodeFeed: %w", err)
+	}
+	return nil
+}
+
+// unpackFeed peels compression layers one by one, archives are unpacked only at the top level.
+func unpackFeed(body io.Reader, meta feedMeta, limits UnpackLimits, opts *optsHolder, archives bool, fn func(row FeedRow) error) error {
+	br := bufio.NewReaderSize(body, 64*1024)
+
+	c, compressedType, compressedExt := detectCompression(meta, br)
+
+	inner := meta
+	inner.ContentEncoding = ""
+	if compressedType {
+		inner.ContentType = ""
+	}
+	if compressedExt {
+		inner.Name = trimExt(meta.Name)
+	}
+
+	switch c {
+	case CompressionGzip:
+		compressed := &countingReader{r: br}
+		zr, err := gzip.NewReader(compressed)
+		if err != nil {
+			return fmt.Errorf("unpackFeed: gzip: %w", err)
+		}
+		defer zr.Close()
+
+		return unpackFeed(newLimitedReader(zr, limits, compressed.count), inner, limits, opts, archives, fn)
+	case CompressionZstd:
+		compressed := &countingReader{r: br}
+		zr, err := zstd.NewReader(compressed, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return fmt.Errorf("unpackFeed: zstd: %w", err)
+		}
+		defer zr.Close()
+
+		return unpackFeed(newLimitedReader(zr, limits, compressed.count), inner, limits, opts, archives, fn)
+	case CompressionZip:
+		if !archives {
+			return fmt.Errorf("unpackFeed: %s: nested archives are not supported", meta.Name)
+		}
+		if err := decodeZip(br, limits, opts, fn); err != nil {
+			return fmt.Errorf("unpackFeed: %w", err)
+		}
+		return nil
+	}
+
+	schema := opts.schema
+	utf8Body, known, err := toUTF8(br, schema.Charset, meta.ContentType)
+	if err != nil {
+		return fmt.Errorf("unpackFeed: %w", err)
+	}
+	if known {
+		schema.Charset = charsetUTF8
+	}
+	ubr := bufio.NewReaderSize(utf8Body, 64*1024)
+
+	format := detectFormat(opts.format, meta.ContentType, meta.Name, schema, ubr)
+	decode := feedDecoders[format]
+
+	if err := decode(ubr, schema, fn); err != nil {
+		return fmt.Errorf("unpackFeed: %s: %w", format, err)
+	}
+
+	return nil
+}
+
+// decodeZip spools the archive to a temp file, zip central directory is at the end.
+func decodeZip(body io.Reader, limits UnpackLimits, opts *optsHolder, fn func(row FeedRow) error) error {
+	tmp, size, err := spool(body, "feed-*.zip", limits.MaxSize)
+	if err != nil {
+		return fmt.Errorf("decodeZip: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	zr, err := zip.NewReader(tmp, size)
+	if err != nil {
+		return fmt.Errorf("decodeZip: %w", err)
+	}
+
+	matched := 0
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !opts.archive.match(f.Name) {
+			continue
+		}
+		matched++
+
+		if err := decodeZipEntry(f, limits, opts, fn); err != nil {
+			return fmt.Errorf("decodeZip: %s: %w", f.Name, err)
+		}
+
+		if !opts.archive.All {
+			break
+		}
+	}
+
+	if matched == 0 {
+		return fmt.Errorf("decodeZip: no entries matching %q", opts.archive.Pattern)
+	}
+
+	return nil
+}
+
+// spool copies body to a temp file positioned at its start, maxSize is unlimited if zero.
+// The caller removes the file.
+func spool(body io.Reader, pattern string, maxSize int64) (*os.File, int64, error) {
+	tmp, err := ioutil.TempFile("", pattern)
+	if err != nil {
+		return nil, 0, fmt.Errorf("spool: %w", err)
+	}
+
+	src := body
+	if maxSize > 0 {
+		src = io.LimitReader(body, maxSize+1)
+	}
+	size, err := io.Copy(tmp, src)
+	if err == nil && maxSize > 0 && size > maxSize {
+		err = fmt.Errorf("body is larger than %d bytes", maxSize)
+	}
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, 0, fmt.Errorf("spool: %w", err)
+	}
+
+	return tmp, size, nil
+}
+
+func decodeZipEntry(f *zip.File, limits UnpackLimits, opts *optsHolder, fn func(row FeedRow) error) error {
+	if limits.MaxSize > 0 && f.UncompressedSize64 > uint64(limits.MaxSize) {
+		return fmt.Errorf("decodeZipEntry: entry is larger than %d bytes", limits.MaxSize)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("decodeZipEntry: %w", err)
+	}
+	defer rc.Close()
+
+	// sizes in headers may lie, so actual output is checked as well
+	compressedSize := func() int64 {
+		return int64(f.CompressedSize64)
+	}
+
+	err = unpackFeed(newLimitedReader(rc, limits, compressedSize), feedMeta{Name: f.Name}, limits, opts, false, func(row FeedRow) error {
+		row.Entry = f.Name
+		return fn(row)
+	})
+	if err != nil {
+		return fmt.Errorf("decodeZipEntry: %w", err)
+	}
+
+	return nil
+}
+
+// countingReader is counted atomically, zstd decoder reads compressed input in its own goroutine.
+type countingReader struct {
+	// first for 64-bit alignment of atomic access
+	n int64
+	r io.Reader
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	atomic.AddInt64(&r.n, int64(n))
+	return n, err
+}
+
+func (r *countingReader) count() int64 {
+	return atomic.LoadInt64(&r.n)
+}
+
+// limitedReader fails once decompressed output exceeds UnpackLimits.
+type limitedReader struct {
+	r          io.Reader
+	limits     UnpackLimits
+	compressed func() int64
+	n          int64
+}
+
+func newLimitedReader(r io.Reader, limits UnpackLimits, compressed func() int64) *limitedReader {
+	return &limitedReader{
+		r:          r,
+		limits:     limits,
+		compressed: compressed,
+	}
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+
+	if r.limits.MaxSize > 0 && r.n > r.limits.MaxSize {
+		return n, fmt.Errorf("Read: decompressed feed is larger than %d bytes", r.limits.MaxSize)
+	}
+
+	if r.limits.MaxRatio > 0 && r.n > ratioCheckThreshold {
+		ratio := float64(r.n) / float64(r.compressed())
+		if ratio > r.limits.MaxRatio {
+			return n, fmt.Errorf("Read: compression ratio %.0f exceeds %.0f", ratio, r.limits.MaxRatio)
+		}
+	}
+
+	return n, err
+}
@@ -0,0 +1,113 @@
+package products
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// 1.2MB of rows, above ratioCheckThreshold and compressed far more than 100 times
+const unpackTestRows = 120000
+
+func unpackTestFeed() []byte {
+	return []byte("name;price\n" + strings.Repeat("product;1\n", unpackTestRows))
+}
+
+func gzipFeed(t *testing.T, feed []byte) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(feed); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zstdFeed(t *testing.T, feed []byte) []byte {
+	var buf bytes.Buffer
+	zw, err := zstd.NewWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := zw.Write(feed); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zipFeed(t *testing.T, feed []byte) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("feed.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(feed); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeFeedLimits(t *testing.T) {
+	feed := unpackTestFeed()
+	packs := []struct {
+		name string
+		pack func(t *testing.T, feed []byte) []byte
+	}{
+		{"feed.csv.gz", gzipFeed},
+		{"feed.csv.zst", zstdFeed},
+		{"feed.zip", zipFeed},
+		{"feed.csv.gz.gz", func(t *testing.T, feed []byte) []byte { return gzipFeed(t, gzipFeed(t, feed)) }},
+	}
+	tests := []struct {
+		name    string
+		limits  UnpackLimits
+		wantErr string
+	}{
+		{name: "unlimited"},
+		{name: "within limits", limits: UnpackLimits{MaxSize: 4 << 20, MaxRatio: 100000}},
+		{name: "too large", limits: UnpackLimits{MaxSize: 1 << 20}, wantErr: "larger than"},
+		{name: "ratio exceeded", limits: UnpackLimits{MaxRatio: 50}, wantErr: "ratio"},
+		{name: "limit below compressed size", limits: UnpackLimits{MaxSize: 100}, wantErr: "larger than"},
+	}
+
+	for _, p := range packs {
+		packed := p.pack(t, feed)
+		for _, tt := range tests {
+			t.Run(p.name+"/"+tt.name, func(t *testing.T) {
+				rows := 0
+				err := decodeFeed(bytes.NewReader(packed), feedMeta{Name: p.name}, tt.limits, &optsHolder{}, func(row FeedRow) error {
+					if row.Err != nil {
+						t.Fatalf("line %d: %v", row.Line, row.Err)
+					}
+					rows++
+					return nil
+				})
+				if tt.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Fatalf("decodeFeed() error = %v, want %q", err, tt.wantErr)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("decodeFeed() error = %v", err)
+				}
+				if rows != unpackTestRows {
+					t.Errorf("decodeFeed() rows = %d, want %d", rows, unpackTestRows)
+				}
+			})
+		}
+	}
+}
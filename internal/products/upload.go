@@ -0,0 +1,178 @@
+package products
+
+import (
+	"context"
+	goErrors "errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/marknovikov/products-demo/internal/errors"
+)
+
+// uploadPrefix tells uploads from downloaded feeds in job urls, price history and full sync.
+const uploadPrefix = "upload:"
+
+// UploadChunk is either a piece of raw feed or a batch of products, a single upload never mixes them.
+type UploadChunk struct {
+	// raw feed in any format Fetch supports, possibly compressed
+	Data     []byte
+	Products []UploadedProduct
+}
+
+// UploadedProduct is validated and priced the same way as feed rows are.
+type UploadedProduct struct {
+	Name       string
+	Price      string
+	ExternalID string
+	Currency   string
+}
+
+func (p UploadedProduct) record() []string {
+	return []string{p.Name, p.Price, p.ExternalID, p.Currency}
+}
+
+func (p UploadedProduct) values() map[string]string {
+	values := map[string]string{
+		fieldName:  p.Name,
+		fieldPrice: p.Price,
+	}
+	if p.ExternalID != "" {
+		values[fieldExternalID] = p.ExternalID
+	}
+	if p.Currency != "" {
+		values[fieldCurrency] = p.Currency
+	}
+	return values
+}
+
+// FeedUpload is the feed pushed by the caller, read once by the fetch job.
+type FeedUpload interface {
+	// Next returns io.EOF once the upload is over.
+	Next() (UploadChunk, error)
+}
+
+// UploadFeed waits for the job in flight for the same upload name or source, if any.
+// The job failed or canceled is returned along with the error.
+// name is the feed file name telling format and compression by its extension,
+// it identifies the upload like url does for Fetch unless WithSource is given.
+func (s *service) UploadFeed(ctx context.Context, name string, upload FeedUpload, opts ...option) (FetchJob, error) {
+	source := applyOptions(opts).source
+	if source != "" {
+		var err error
+		if _, opts, err = s.sourceOptions(ctx, source, opts); err != nil {
+			return FetchJob{}, fmt.Errorf("UploadFeed: %w", err)
+		}
+	}
+	if strings.TrimSpace(name) == "" && source == "" {
+		return FetchJob{}, errors.NewErrInvalidInput(fmt.Errorf("UploadFeed: upload name or source is required"))
+	}
+
+	if s.jobs.closed() {
+		return FetchJob{}, errors.NewErrInternal(fmt.Errorf("UploadFeed: service is shutting down"))
+	}
+
+	// the upload can't be handed over to the job in flight, so it waits for its turn
+	job, _, err := s.lockFetch(ctx, FetchJob{URL: uploadPrefix + name, Source: source}, ConflictWait)
+	if err != nil {
+		return job, fmt.Errorf("UploadFeed: %w", err)
+	}
+
+	opts = append(opts, Options().withUpload(upload))
+	done := s.jobs.start(job.ID, func(ctx context.Context) {
+		s.runFetchJob(ctx, job, opts)
+	})
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// the upload is gone with the caller
+		s.jobs.cancel(job.ID)
+		<-done
+		return job, fmt.Errorf("UploadFeed: job %s: %w", job.ID, ctx.Err())
+	}
+
+	job, err = s.storage.FindFetchJob(ctx, job.ID)
+	if err != nil {
+		return job, fmt.Errorf("UploadFeed: %w", err)
+	}
+	if job.State != FetchJobStateSucceeded {
+		return job, fmt.Errorf("UploadFeed: job %s %s: %s", job.ID, job.State, job.Error)
+	}
+
+	return job, nil
+}
+
+// listUpload streams rows of the upload to fn, raw feed is parsed by the client the way downloaded one is.
+func (s *service) listUpload(ctx context.Context, run *fetchRun, fn func(row FeedRow) error) error {
+	first, err := run.upload.Next()
+	if goErrors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("listUpload: %w", err)
+	}
+
+	if first.Products == nil {
+		body := &uploadReader{upload: run.upload, chunk: first.Data}
+		name := strings.TrimPrefix(run.path, uploadPrefix)
+		if err := s.client.Decode(name, body, fn, run.opts...); err != nil {
+			return fmt.Errorf("listUpload: %w", err)
+		}
+		return nil
+	}
+
+	prices := applyOptions(run.opts).schema.Price
+
+	var line uint32
+	for chunk := first; ; {
+		if len(chunk.Data) > 0 {
+			return fmt.Errorf("listUpload: raw feed after products")
+		}
+
+		for _, p := range chunk.Products {
+			line++
+			product, err := newFeedProduct(p.values(), prices)
+			row := FeedRow{
+				Line:    line,
+				Record:  p.record(),
+				Product: product,
+				Err:     err,
+			}
+			if err := fn(row); err != nil {
+				return fmt.Errorf("listUpload: %w", err)
+			}
+		}
+
+		chunk, err = run.upload.Next()
+		if goErrors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("listUpload: %w", err)
+		}
+	}
+}
+
+// uploadReader reads raw feed chunk by chunk.
+type uploadReader struct {
+	upload FeedUpload
+	chunk  []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		chunk, err := r.upload.Next()
+		if err != nil {
+			return 0, err
+		}
+		if chunk.Products != nil {
+			return 0, fmt.Errorf("Read: products after raw feed")
+		}
+		r.chunk = chunk.Data
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
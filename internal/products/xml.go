@@ -0,0 +1,194 @@
+package products
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const defaultXMLRecordPath = "offer"
+
+// decodeXML streams record elements (YML offers by default) one at a time,
+// so the document is never loaded as a whole.
+// Record fields are addressed by paths relative to the record element: "@id", "price", "price/@currency".
+// FeedRow.Line is the record number, not the line of the feed.
+func decodeXML(body io.Reader, schema FeedSchema, fn func(row FeedRow) error) error {
+	dec := xml.NewDecoder(body)
+	// real world feeds are full of html entities and sloppy markup in descriptions
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+	dec.CharsetReader = xmlCharsetReader
+	if schema.Charset != "" {
+		// already transcoded, declared encoding is stale
+		dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+			return input, nil
+		}
+	}
+
+	recordPath := schema.RecordPath
+	if recordPath == "" {
+		recordPath = defaultXMLRecordPath
+	}
+	recordElems := strings.Split(strings.Trim(recordPath, "/"), "/")
+
+	var (
+		stack []string
+		n     uint32
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("decodeXML: record %d: %w", n+1, err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			stack = append(stack, t.Name.Local)
+			if !hasPathSuffix(stack, recordElems) {
+				continue
+			}
+
+			rec, err := readXMLRecord(dec, t)
+			if err != nil {
+				return fmt.Errorf("decodeXML: record %d: %w", n+1, err)
+			}
+			stack = stack[:len(stack)-1]
+			n++
+
+			if err := fn(xmlRecordToRow(rec, schema, n)); err != nil {
+				return fmt.Errorf("decodeXML: %w", err)
+			}
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+}
+
+func hasPathSuffix(stack, suffix []string) bool {
+	if len(stack) < len(suffix) {
+		return false
+	}
+
+	stack = stack[len(stack)-len(suffix):]
+	for i := range suffix {
+		if stack[i] != suffix[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// xmlRecord holds the first value of every path within the record element in document order.
+type xmlRecord struct {
+	paths  []string
+	values map[string]string
+}
+
+func (r *xmlRecord) add(path, value string) {
+	if _, ok := r.values[path]; ok {
+		return
+	}
+	r.paths = append(r.paths, path)
+	r.values[path] = value
+}
+
+func (r *xmlRecord) record() []string {
+	record := make([]string, len(r.paths))
+	for i, path := range r.paths {
+		record[i] = path + "=" + r.values[path]
+	}
+	return record
+}
+
+// readXMLRecord reads the record element which start is already consumed up to its end inclusive.
+func readXMLRecord(dec *xml.Decoder, start xml.StartElement) (xmlRecord, error) {
+	rec := xmlRecord{values: make(map[string]string)}
+
+	for _, attr := range start.Attr {
+		rec.add("@"+attr.Name.Local, attr.Value)
+	}
+
+	var (
+		path  []string
+		texts []*strings.Builder
+	)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return rec, fmt.Errorf("readXMLRecord: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			path = append(path, t.Name.Local)
+			texts = append(texts, &strings.Builder{})
+
+			prefix := strings.Join(path, "/")
+			for _, attr := range t.Attr {
+				rec.add(prefix+"/@"+attr.Name.Local, attr.Value)
+			}
+		case xml.CharData:
+			if len(texts) > 0 {
+				texts[len(texts)-1].Write(t)
+			}
+		case xml.EndElement:
+			if len(path) == 0 {
+				return rec, nil
+			}
+
+			rec.add(strings.Join(path, "/"), strings.TrimSpace(texts[len(texts)-1].String()))
+
+			path = path[:len(path)-1]
+			texts = texts[:len(texts)-1]
+		}
+	}
+}
+
+func xmlRecordToRow(rec xmlRecord, schema FeedSchema, line uint32) FeedRow {
+	row := FeedRow{
+		Line:   line,
+		Record: rec.record(),
+	}
+	row.Product, row.Err = xmlRecordToProduct(rec, schema)
+
+	return row
+}
+
+func xmlRecordToProduct(rec xmlRecord, schema FeedSchema) (Product, error) {
+	values := make(map[string]string, len(productFields))
+	for _, path := range rec.paths {
+		field, ok := fieldByColumnName(schema, path)
+		if !ok {
+			continue
+		}
+		if _, ok := values[field]; !ok {
+			values[field] = rec.values[path]
+		}
+	}
+
+	// YML vendor.model offers have no name, it is made of type prefix, vendor and model
+	if _, ok := values[fieldName]; !ok && len(schema.Columns) == 0 && rec.values["model"] != "" {
+		var parts []string
+		for _, path := range []string{"typePrefix", "vendor", "model"} {
+			if v := rec.values[path]; v != "" {
+				parts = append(parts, v)
+			}
+		}
+		values[fieldName] = strings.Join(parts, " ")
+	}
+
+	p, err := newFeedProduct(values, schema.Price)
+	if err != nil {
+		return p, fmt.Errorf("xmlRecordToProduct: %w", err)
+	}
+
+	return p, nil
+}
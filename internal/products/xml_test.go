@@ -0,0 +1,100 @@
+package products
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeXML(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema FeedSchema
+		feed   string
+		// see rowString
+		rows    []string
+		wantErr bool
+	}{
+		{
+			name: "yml offers",
+			feed: `<?xml version="1.0"?><yml_catalog><shop><currencies><currency id="RUR" rate="1"/></currencies>` +
+				`<offers><offer id="1"><name>a</name><price>1</price></offer><offer id="2"><name>b</name><price>2.50</price></offer></offers></shop></yml_catalog>`,
+			rows: []string{"1 a 1", "2 b 2.5"},
+		},
+		{
+			name: "vendor model offer",
+			feed: `<offers><offer type="vendor.model"><typePrefix>Phone</typePrefix><vendor>Acme</vendor><model>X1</model><price>1</price></offer></offers>`,
+			rows: []string{"1 Phone Acme X1 1"},
+		},
+		{
+			name: "first of paths mapped to the same field wins",
+			feed: `<offer><title>t</title><name>n</name><price>1</price></offer>`,
+			rows: []string{"1 t 1"},
+		},
+		{
+			name: "html entities and sloppy markup",
+			feed: `<offer><name>&laquo;a&raquo; &amp; b</name><description>line<br>break</description><price>1</price></offer>`,
+			rows: []string{"1 «a» & b 1"},
+		},
+		{
+			name:   "record path matched by suffix",
+			schema: FeedSchema{RecordPath: "items/item"},
+			feed: `<catalog><items><item><name>a</name><price>1</price></item></items>` +
+				`<related><item><name>b</name><price>2</price></item></related>` +
+				`<archive><items><item><name>c</name><price>3</price></item></items></archive></catalog>`,
+			rows: []string{"1 a 1", "2 c 3"},
+		},
+		{
+			name:   "record path slashes trimmed",
+			schema: FeedSchema{RecordPath: "/catalog/items/item/"},
+			feed: `<catalog><items><item><name>a</name><price>1</price></item></items>` +
+				`<archive><items><item><name>c</name><price>3</price></item></items></archive></catalog>`,
+			rows: []string{"1 a 1"},
+		},
+		{
+			name:   "single element record path at any depth",
+			schema: FeedSchema{RecordPath: "item"},
+			feed:   `<catalog><item><name>a</name><price>1</price></item><group><item><name>b</name><price>2</price></item></group></catalog>`,
+			rows:   []string{"1 a 1", "2 b 2"},
+		},
+		{
+			name:   "record element nested in a record",
+			schema: FeedSchema{RecordPath: "item"},
+			feed:   `<catalog><item><name>a</name><price>1</price><item><name>b</name><price>2</price></item></item></catalog>`,
+			rows:   []string{"1 a 1"},
+		},
+		{
+			name:   "mapped paths",
+			schema: FeedSchema{Columns: map[string]string{"info/title": fieldName, "price/@value": fieldPrice}},
+			feed:   `<offer><name>n</name><info><title>t</title></info><price value="3">ignored</price></offer>`,
+			rows:   []string{"1 t 3"},
+		},
+		{
+			name: "rejected record",
+			feed: `<offers><offer><name>a</name></offer><offer><name>b</name><price>2</price></offer></offers>`,
+			rows: []string{"1 error", "2 b 2"},
+		},
+		{
+			name:    "truncated",
+			feed:    `<offers><offer><name>a</name><price>1</price></offer><offer><name>b`,
+			rows:    []string{"1 a 1"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rows []string
+			err := decodeXML(strings.NewReader(tt.feed), tt.schema, func(row FeedRow) error {
+				rows = append(rows, rowString(row))
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeXML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("rows = %q, want %q", rows, tt.rows)
+			}
+		})
+	}
+}
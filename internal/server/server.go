@@ -1,9 +1,11 @@
 package server
 
 import (
+	_ "expvar"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 
@@ -26,6 +28,7 @@ func Server(c *cli.Context) error {
 		Database:     cfg.MongoDatabase,
 		ConnTimeout:  cfg.MongoConnTimeout,
 		QueryTimeout: cfg.MongoQueryTimeout,
+		SecretsKey:   cfg.SecretsKey,
 	}
 
 	mongoConn, closeMongo, err := products.NewMongoConn(storageConfig)
@@ -39,13 +42,79 @@ func Server(c *cli.Context) error {
 		log.Fatal(err)
 	}
 
-	httpCli := products.NewClient(products.ClientConfig{
+	fileRoots := cfg.FetchFileRoots
+	if cfg.InboxDir != "" {
+		// inbox files are fetched by their file:// urls
+		fileRoots = append(fileRoots, cfg.InboxDir)
+	}
+
+	httpCli, err := products.NewClient(products.ClientConfig{
 		HttpTimeout: cfg.HTTPTimeout,
+		Unpack: products.UnpackLimits{
+			MaxSize:  cfg.FetchMaxDecompressedSize,
+			MaxRatio: cfg.FetchMaxCompressionRatio,
+		},
+		Egress: products.EgressPolicy{
+			Schemes:         cfg.FetchAllowedSchemes,
+			AllowHosts:      cfg.FetchAllowedHosts,
+			DenyHosts:       cfg.FetchDeniedHosts,
+			AllowPrivate:    cfg.FetchAllowPrivate,
+			MaxRedirects:    cfg.FetchMaxRedirects,
+			MaxResponseSize: cfg.FetchMaxResponseSize,
+		},
+		SecretsDir: cfg.SecretsDir,
+		FileRoots:  fileRoots,
+		S3: products.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			PathStyle:       cfg.S3PathStyle,
+		},
+		Retry: products.RetryPolicy{
+			MaxAttempts: cfg.FetchRetryAttempts,
+			BaseDelay:   cfg.FetchRetryBaseDelay,
+			MaxDelay:    cfg.FetchRetryMaxDelay,
+		},
+		Breaker: products.BreakerPolicy{
+			Failures: cfg.FetchBreakerFailures,
+			Cooldown: cfg.FetchBreakerCooldown,
+		},
 	})
+	if err != nil {
+		return fmt.Errorf("server: %w", err)
+	}
+
+	productsSvc := products.NewService(httpCli, storage, products.ServiceConfig{
+		FetchTimeout:          cfg.FetchTimeout,
+		FetchJobHeartbeat:     cfg.FetchJobHeartbeat,
+		FetchJobUpdateTimeout: cfg.MongoQueryTimeout,
+		MaxReportErrors:       cfg.FetchMaxErrors,
+		BatchSize:             cfg.FetchBatchSize,
+		BatchQueue:            cfg.FetchBatchQueue,
+		MaxDiffItems:          cfg.FetchMaxDiffItems,
+		ScheduleTick:          cfg.ScheduleTick,
+		ScheduleLease:         cfg.ScheduleLease,
+		InboxDir:              cfg.InboxDir,
+		InboxPoll:             cfg.InboxPoll,
+		InboxSettle:           cfg.InboxSettle,
+		FetchWorkers:          cfg.FetchWorkers,
+		FetchWorkersPerHost:   cfg.FetchWorkersPerHost,
+		MaxBatchFeeds:         cfg.FetchBatchMaxFeeds,
+	})
+	defer productsSvc.Close()
 
-	productsSvc := products.NewService(httpCli, storage)
 	productsGrpcServer := products.NewGrpcServer(productsSvc)
 
+	if cfg.MetricsPort > 0 {
+		// expvar serves counters on /debug/vars
+		go func() {
+			if err := http.ListenAndServe(fmt.Sprintf(":%d", cfg.MetricsPort), nil); err != nil {
+				log.Printf("server: metrics: %v", err)
+			}
+		}()
+	}
+
 	lis, err := net.Listen("tcp", ":8080")
 	if err != nil {
 		return fmt.Errorf("server: %w", err)
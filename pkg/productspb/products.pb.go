@@ -21,304 +21,515 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// downloads csv of form product_name;price by given url
-// writes downloaded products to mongo updating price as necessary with update count and time
-type FetchRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+type FeedFormat int32
 
-	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-}
+const (
+	FeedFormat_DETECT FeedFormat = 0
+	FeedFormat_CSV    FeedFormat = 1
+	// array of product objects, possibly wrapped into an object
+	FeedFormat_JSON FeedFormat = 2
+	// product object per line
+	FeedFormat_NDJSON FeedFormat = 3
+	// YML (Yandex Market Language) offers or other xml dialect described by FeedSchema
+	FeedFormat_XML FeedFormat = 4
+)
 
-func (x *FetchRequest) Reset() {
-	*x = FetchRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_products_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+// Enum value maps for FeedFormat.
+var (
+	FeedFormat_name = map[int32]string{
+		0: "DETECT",
+		1: "CSV",
+		2: "JSON",
+		3: "NDJSON",
+		4: "XML",
+	}
+	FeedFormat_value = map[string]int32{
+		"DETECT": 0,
+		"CSV":    1,
+		"JSON":   2,
+		"NDJSON": 3,
+		"XML":    4,
 	}
+)
+
+func (x FeedFormat) Enum() *FeedFormat {
+	p := new(FeedFormat)
+	*p = x
+	return p
 }
 
-func (x *FetchRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x FeedFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (*FetchRequest) ProtoMessage() {}
+func (FeedFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_products_proto_enumTypes[0].Descriptor()
+}
 
-func (x *FetchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_products_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (FeedFormat) Type() protoreflect.EnumType {
+	return &file_api_products_proto_enumTypes[0]
 }
 
-// Deprecated: Use FetchRequest.ProtoReflect.Descriptor instead.
-func (*FetchRequest) Descriptor() ([]byte, []int) {
+func (x FeedFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FeedFormat.Descriptor instead.
+func (FeedFormat) EnumDescriptor() ([]byte, []int) {
 	return file_api_products_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *FetchRequest) GetUrl() string {
-	if x != nil {
-		return x.Url
+// what to do if the same source or url is being fetched already by any instance,
+// ignored by dry run
+type FetchRequest_OnConflict int32
+
+const (
+	// return the job in flight instead of starting a new one, wait applies to that job
+	FetchRequest_JOIN FetchRequest_OnConflict = 0
+	// wait for the job in flight to finish and start a new one then, the call blocks meanwhile
+	FetchRequest_WAIT FetchRequest_OnConflict = 1
+	// fail with ALREADY_EXISTS, job id of the job in flight is returned
+	FetchRequest_REJECT FetchRequest_OnConflict = 2
+)
+
+// Enum value maps for FetchRequest_OnConflict.
+var (
+	FetchRequest_OnConflict_name = map[int32]string{
+		0: "JOIN",
+		1: "WAIT",
+		2: "REJECT",
 	}
-	return ""
-}
+	FetchRequest_OnConflict_value = map[string]int32{
+		"JOIN":   0,
+		"WAIT":   1,
+		"REJECT": 2,
+	}
+)
 
-// empty
-type FetchResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x FetchRequest_OnConflict) Enum() *FetchRequest_OnConflict {
+	p := new(FetchRequest_OnConflict)
+	*p = x
+	return p
 }
 
-func (x *FetchResponse) Reset() {
-	*x = FetchResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_products_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (x FetchRequest_OnConflict) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *FetchResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (FetchRequest_OnConflict) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_products_proto_enumTypes[1].Descriptor()
 }
 
-func (*FetchResponse) ProtoMessage() {}
+func (FetchRequest_OnConflict) Type() protoreflect.EnumType {
+	return &file_api_products_proto_enumTypes[1]
+}
 
-func (x *FetchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_products_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (x FetchRequest_OnConflict) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use FetchResponse.ProtoReflect.Descriptor instead.
-func (*FetchResponse) Descriptor() ([]byte, []int) {
-	return file_api_products_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use FetchRequest_OnConflict.Descriptor instead.
+func (FetchRequest_OnConflict) EnumDescriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{0, 0}
 }
 
-type Product struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+type SyncPolicy_Mode int32
 
-	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Price            string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
-	PriceUpdateCount uint32                 `protobuf:"varint,4,opt,name=priceUpdateCount,proto3" json:"priceUpdateCount,omitempty"`
-	LastModified     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=lastModified,proto3" json:"lastModified,omitempty"`
-}
+const (
+	// keep them as is
+	SyncPolicy_UPSERT SyncPolicy_Mode = 0
+	// archive products last listed by the same url or source, only once the feed is read in full
+	SyncPolicy_FULL SyncPolicy_Mode = 1
+)
 
-func (x *Product) Reset() {
-	*x = Product{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_products_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+// Enum value maps for SyncPolicy_Mode.
+var (
+	SyncPolicy_Mode_name = map[int32]string{
+		0: "UPSERT",
+		1: "FULL",
+	}
+	SyncPolicy_Mode_value = map[string]int32{
+		"UPSERT": 0,
+		"FULL":   1,
 	}
+)
+
+func (x SyncPolicy_Mode) Enum() *SyncPolicy_Mode {
+	p := new(SyncPolicy_Mode)
+	*p = x
+	return p
 }
 
-func (x *Product) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x SyncPolicy_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (*Product) ProtoMessage() {}
+func (SyncPolicy_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_products_proto_enumTypes[2].Descriptor()
+}
 
-func (x *Product) ProtoReflect() protoreflect.Message {
-	mi := &file_api_products_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (SyncPolicy_Mode) Type() protoreflect.EnumType {
+	return &file_api_products_proto_enumTypes[2]
 }
 
-// Deprecated: Use Product.ProtoReflect.Descriptor instead.
-func (*Product) Descriptor() ([]byte, []int) {
-	return file_api_products_proto_rawDescGZIP(), []int{2}
+func (x SyncPolicy_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *Product) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use SyncPolicy_Mode.Descriptor instead.
+func (SyncPolicy_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{1, 0}
 }
 
-func (x *Product) GetName() string {
-	if x != nil {
-		return x.Name
+type FeedSchema_Quotes int32
+
+const (
+	// RFC 4180
+	FeedSchema_STRICT FeedSchema_Quotes = 0
+	// quotes may appear in unquoted fields, quoted fields may have non-doubled quotes
+	FeedSchema_LAZY FeedSchema_Quotes = 1
+	// quotes are ordinary characters
+	FeedSchema_NONE FeedSchema_Quotes = 2
+)
+
+// Enum value maps for FeedSchema_Quotes.
+var (
+	FeedSchema_Quotes_name = map[int32]string{
+		0: "STRICT",
+		1: "LAZY",
+		2: "NONE",
 	}
-	return ""
+	FeedSchema_Quotes_value = map[string]int32{
+		"STRICT": 0,
+		"LAZY":   1,
+		"NONE":   2,
+	}
+)
+
+func (x FeedSchema_Quotes) Enum() *FeedSchema_Quotes {
+	p := new(FeedSchema_Quotes)
+	*p = x
+	return p
 }
 
-func (x *Product) GetPrice() string {
-	if x != nil {
-		return x.Price
-	}
-	return ""
+func (x FeedSchema_Quotes) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *Product) GetPriceUpdateCount() uint32 {
-	if x != nil {
-		return x.PriceUpdateCount
-	}
-	return 0
+func (FeedSchema_Quotes) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_products_proto_enumTypes[3].Descriptor()
 }
 
-func (x *Product) GetLastModified() *timestamppb.Timestamp {
-	if x != nil {
-		return x.LastModified
-	}
-	return nil
+func (FeedSchema_Quotes) Type() protoreflect.EnumType {
+	return &file_api_products_proto_enumTypes[3]
 }
 
-// returns a requested page of products
-// able to sort by any product's field
-// what if I change sorting method for arbitrary page?
-type ListRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x FeedSchema_Quotes) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
 
-	Paging  *ListRequest_Paging  `protobuf:"bytes,1,opt,name=paging,proto3" json:"paging,omitempty"`
-	Sorting *ListRequest_Sorting `protobuf:"bytes,2,opt,name=sorting,proto3" json:"sorting,omitempty"`
+// Deprecated: Use FeedSchema_Quotes.Descriptor instead.
+func (FeedSchema_Quotes) EnumDescriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{3, 0}
 }
 
-func (x *ListRequest) Reset() {
-	*x = ListRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_products_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+type FeedSchema_Header int32
+
+const (
+	FeedSchema_DETECT  FeedSchema_Header = 0
+	FeedSchema_PRESENT FeedSchema_Header = 1
+	FeedSchema_ABSENT  FeedSchema_Header = 2
+)
+
+// Enum value maps for FeedSchema_Header.
+var (
+	FeedSchema_Header_name = map[int32]string{
+		0: "DETECT",
+		1: "PRESENT",
+		2: "ABSENT",
+	}
+	FeedSchema_Header_value = map[string]int32{
+		"DETECT":  0,
+		"PRESENT": 1,
+		"ABSENT":  2,
 	}
+)
+
+func (x FeedSchema_Header) Enum() *FeedSchema_Header {
+	p := new(FeedSchema_Header)
+	*p = x
+	return p
 }
 
-func (x *ListRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x FeedSchema_Header) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (*ListRequest) ProtoMessage() {}
+func (FeedSchema_Header) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_products_proto_enumTypes[4].Descriptor()
+}
 
-func (x *ListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_products_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (FeedSchema_Header) Type() protoreflect.EnumType {
+	return &file_api_products_proto_enumTypes[4]
 }
 
-// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
-func (*ListRequest) Descriptor() ([]byte, []int) {
-	return file_api_products_proto_rawDescGZIP(), []int{3}
+func (x FeedSchema_Header) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *ListRequest) GetPaging() *ListRequest_Paging {
-	if x != nil {
-		return x.Paging
-	}
-	return nil
+// Deprecated: Use FeedSchema_Header.Descriptor instead.
+func (FeedSchema_Header) EnumDescriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{3, 1}
 }
 
-func (x *ListRequest) GetSorting() *ListRequest_Sorting {
-	if x != nil {
-		return x.Sorting
+type ErrorPolicy_Mode int32
+
+const (
+	ErrorPolicy_ABORT ErrorPolicy_Mode = 0
+	ErrorPolicy_SKIP  ErrorPolicy_Mode = 1
+)
+
+// Enum value maps for ErrorPolicy_Mode.
+var (
+	ErrorPolicy_Mode_name = map[int32]string{
+		0: "ABORT",
+		1: "SKIP",
 	}
-	return nil
+	ErrorPolicy_Mode_value = map[string]int32{
+		"ABORT": 0,
+		"SKIP":  1,
+	}
+)
+
+func (x ErrorPolicy_Mode) Enum() *ErrorPolicy_Mode {
+	p := new(ErrorPolicy_Mode)
+	*p = x
+	return p
 }
 
-type ListResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x ErrorPolicy_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
 
-	Products []*Product `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+func (ErrorPolicy_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_products_proto_enumTypes[5].Descriptor()
 }
 
-func (x *ListResponse) Reset() {
-	*x = ListResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_products_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (ErrorPolicy_Mode) Type() protoreflect.EnumType {
+	return &file_api_products_proto_enumTypes[5]
 }
 
-func (x *ListResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x ErrorPolicy_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (*ListResponse) ProtoMessage() {}
+// Deprecated: Use ErrorPolicy_Mode.Descriptor instead.
+func (ErrorPolicy_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{4, 0}
+}
 
-func (x *ListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_products_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+type FetchJob_State int32
+
+const (
+	FetchJob_PENDING   FetchJob_State = 0
+	FetchJob_RUNNING   FetchJob_State = 1
+	FetchJob_SUCCEEDED FetchJob_State = 2
+	FetchJob_FAILED    FetchJob_State = 3
+	FetchJob_CANCELED  FetchJob_State = 4
+)
+
+// Enum value maps for FetchJob_State.
+var (
+	FetchJob_State_name = map[int32]string{
+		0: "PENDING",
+		1: "RUNNING",
+		2: "SUCCEEDED",
+		3: "FAILED",
+		4: "CANCELED",
 	}
-	return mi.MessageOf(x)
+	FetchJob_State_value = map[string]int32{
+		"PENDING":   0,
+		"RUNNING":   1,
+		"SUCCEEDED": 2,
+		"FAILED":    3,
+		"CANCELED":  4,
+	}
+)
+
+func (x FetchJob_State) Enum() *FetchJob_State {
+	p := new(FetchJob_State)
+	*p = x
+	return p
 }
 
-// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
-func (*ListResponse) Descriptor() ([]byte, []int) {
-	return file_api_products_proto_rawDescGZIP(), []int{4}
+func (x FetchJob_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *ListResponse) GetProducts() []*Product {
-	if x != nil {
-		return x.Products
-	}
-	return nil
+func (FetchJob_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_products_proto_enumTypes[6].Descriptor()
 }
 
-type ListRequest_Paging struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (FetchJob_State) Type() protoreflect.EnumType {
+	return &file_api_products_proto_enumTypes[6]
+}
 
-	Limit uint32   `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
-	Last  *Product `protobuf:"bytes,2,opt,name=last,proto3" json:"last,omitempty"`
+func (x FetchJob_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *ListRequest_Paging) Reset() {
-	*x = ListRequest_Paging{}
+// Deprecated: Use FetchJob_State.Descriptor instead.
+func (FetchJob_State) EnumDescriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{11, 0}
+}
+
+// circuit breaker of the feed host stops requests to the host failing again and again,
+// the open one fails fetches at once until the cooldown is over, then lets a single trial request through
+type IngestionReport_Breaker int32
+
+const (
+	IngestionReport_CLOSED    IngestionReport_Breaker = 0
+	IngestionReport_OPEN      IngestionReport_Breaker = 1
+	IngestionReport_HALF_OPEN IngestionReport_Breaker = 2
+)
+
+// Enum value maps for IngestionReport_Breaker.
+var (
+	IngestionReport_Breaker_name = map[int32]string{
+		0: "CLOSED",
+		1: "OPEN",
+		2: "HALF_OPEN",
+	}
+	IngestionReport_Breaker_value = map[string]int32{
+		"CLOSED":    0,
+		"OPEN":      1,
+		"HALF_OPEN": 2,
+	}
+)
+
+func (x IngestionReport_Breaker) Enum() *IngestionReport_Breaker {
+	p := new(IngestionReport_Breaker)
+	*p = x
+	return p
+}
+
+func (x IngestionReport_Breaker) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IngestionReport_Breaker) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_products_proto_enumTypes[7].Descriptor()
+}
+
+func (IngestionReport_Breaker) Type() protoreflect.EnumType {
+	return &file_api_products_proto_enumTypes[7]
+}
+
+func (x IngestionReport_Breaker) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IngestionReport_Breaker.Descriptor instead.
+func (IngestionReport_Breaker) EnumDescriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{12, 0}
+}
+
+type PriceChange_Event int32
+
+const (
+	PriceChange_PRICE PriceChange_Event = 0
+	// price fields hold the price at the moment of archiving or restoring
+	PriceChange_ARCHIVED PriceChange_Event = 1
+	PriceChange_RESTORED PriceChange_Event = 2
+)
+
+// Enum value maps for PriceChange_Event.
+var (
+	PriceChange_Event_name = map[int32]string{
+		0: "PRICE",
+		1: "ARCHIVED",
+		2: "RESTORED",
+	}
+	PriceChange_Event_value = map[string]int32{
+		"PRICE":    0,
+		"ARCHIVED": 1,
+		"RESTORED": 2,
+	}
+)
+
+func (x PriceChange_Event) Enum() *PriceChange_Event {
+	p := new(PriceChange_Event)
+	*p = x
+	return p
+}
+
+func (x PriceChange_Event) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PriceChange_Event) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_products_proto_enumTypes[8].Descriptor()
+}
+
+func (PriceChange_Event) Type() protoreflect.EnumType {
+	return &file_api_products_proto_enumTypes[8]
+}
+
+func (x PriceChange_Event) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PriceChange_Event.Descriptor instead.
+func (PriceChange_Event) EnumDescriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{23, 0}
+}
+
+// downloads csv of form product_name;price, json, ndjson or xml by given url
+// writes downloaded products to mongo updating price as necessary with update count and time
+// runs in background, returns fetch job id right away unless asked to wait for the job to finish
+type FetchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url         string       `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Wait        bool         `protobuf:"varint,2,opt,name=wait,proto3" json:"wait,omitempty"`
+	ErrorPolicy *ErrorPolicy `protobuf:"bytes,3,opt,name=errorPolicy,proto3" json:"errorPolicy,omitempty"`
+	// detected if omitted
+	Schema *FeedSchema `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
+	// source format if omitted, detected from response content type, url extension or the content itself otherwise
+	Format FeedFormat `protobuf:"varint,5,opt,name=format,proto3,enum=products.FeedFormat" json:"format,omitempty"`
+	// compression is detected from content encoding, content type, url extension or magic bytes
+	Archive *Archive `protobuf:"bytes,6,opt,name=archive,proto3" json:"archive,omitempty"`
+	// runs the feed through parsing and validation and compares it with stored products,
+	// nothing is written and no job is created, the call blocks until the feed is read
+	DryRun bool        `protobuf:"varint,7,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	Sync   *SyncPolicy `protobuf:"bytes,8,opt,name=sync,proto3" json:"sync,omitempty"`
+	// registered source to fetch instead of url, options given along override the source ones
+	Source     string                  `protobuf:"bytes,9,opt,name=source,proto3" json:"source,omitempty"`
+	OnConflict FetchRequest_OnConflict `protobuf:"varint,10,opt,name=onConflict,proto3,enum=products.FetchRequest_OnConflict" json:"onConflict,omitempty"`
+	// ingest the feed even if it is the same as the last time fetched in full by the same source or url,
+	// which is told by ETag, Last-Modified or SHA-256 of the body
+	Force bool `protobuf:"varint,11,opt,name=force,proto3" json:"force,omitempty"`
+}
+
+func (x *FetchRequest) Reset() {
+	*x = FetchRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_products_proto_msgTypes[5]
+		mi := &file_api_products_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListRequest_Paging) String() string {
+func (x *FetchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRequest_Paging) ProtoMessage() {}
+func (*FetchRequest) ProtoMessage() {}
 
-func (x *ListRequest_Paging) ProtoReflect() protoreflect.Message {
-	mi := &file_api_products_proto_msgTypes[5]
+func (x *FetchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -329,51 +540,116 @@ func (x *ListRequest_Paging) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRequest_Paging.ProtoReflect.Descriptor instead.
-func (*ListRequest_Paging) Descriptor() ([]byte, []int) {
-	return file_api_products_proto_rawDescGZIP(), []int{3, 0}
+// Deprecated: Use FetchRequest.ProtoReflect.Descriptor instead.
+func (*FetchRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *ListRequest_Paging) GetLimit() uint32 {
+func (x *FetchRequest) GetUrl() string {
 	if x != nil {
-		return x.Limit
+		return x.Url
 	}
-	return 0
+	return ""
 }
 
-func (x *ListRequest_Paging) GetLast() *Product {
+func (x *FetchRequest) GetWait() bool {
 	if x != nil {
-		return x.Last
+		return x.Wait
+	}
+	return false
+}
+
+func (x *FetchRequest) GetErrorPolicy() *ErrorPolicy {
+	if x != nil {
+		return x.ErrorPolicy
 	}
 	return nil
 }
 
-type ListRequest_Sorting struct {
+func (x *FetchRequest) GetSchema() *FeedSchema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *FetchRequest) GetFormat() FeedFormat {
+	if x != nil {
+		return x.Format
+	}
+	return FeedFormat_DETECT
+}
+
+func (x *FetchRequest) GetArchive() *Archive {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *FetchRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *FetchRequest) GetSync() *SyncPolicy {
+	if x != nil {
+		return x.Sync
+	}
+	return nil
+}
+
+func (x *FetchRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *FetchRequest) GetOnConflict() FetchRequest_OnConflict {
+	if x != nil {
+		return x.OnConflict
+	}
+	return FetchRequest_JOIN
+}
+
+func (x *FetchRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// what to do with products missing from the feed
+type SyncPolicy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ascending bool   `protobuf:"varint,1,opt,name=ascending,proto3" json:"ascending,omitempty"`
-	SortBy    string `protobuf:"bytes,2,opt,name=sortBy,proto3" json:"sortBy,omitempty"`
+	Mode SyncPolicy_Mode `protobuf:"varint,1,opt,name=mode,proto3,enum=products.SyncPolicy_Mode" json:"mode,omitempty"`
+	// consecutive full sync fetches the product must be missing from to get archived, 1 if zero
+	MissedFetches uint32 `protobuf:"varint,2,opt,name=missedFetches,proto3" json:"missedFetches,omitempty"`
 }
 
-func (x *ListRequest_Sorting) Reset() {
-	*x = ListRequest_Sorting{}
+func (x *SyncPolicy) Reset() {
+	*x = SyncPolicy{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_products_proto_msgTypes[6]
+		mi := &file_api_products_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListRequest_Sorting) String() string {
+func (x *SyncPolicy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRequest_Sorting) ProtoMessage() {}
+func (*SyncPolicy) ProtoMessage() {}
 
-func (x *ListRequest_Sorting) ProtoReflect() protoreflect.Message {
-	mi := &file_api_products_proto_msgTypes[6]
+func (x *SyncPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -384,162 +660,3812 @@ func (x *ListRequest_Sorting) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRequest_Sorting.ProtoReflect.Descriptor instead.
-func (*ListRequest_Sorting) Descriptor() ([]byte, []int) {
-	return file_api_products_proto_rawDescGZIP(), []int{3, 1}
+// Deprecated: Use SyncPolicy.ProtoReflect.Descriptor instead.
+func (*SyncPolicy) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *ListRequest_Sorting) GetAscending() bool {
+func (x *SyncPolicy) GetMode() SyncPolicy_Mode {
 	if x != nil {
-		return x.Ascending
+		return x.Mode
 	}
-	return false
+	return SyncPolicy_UPSERT
 }
 
-func (x *ListRequest_Sorting) GetSortBy() string {
+func (x *SyncPolicy) GetMissedFetches() uint32 {
 	if x != nil {
-		return x.SortBy
+		return x.MissedFetches
 	}
-	return ""
+	return 0
 }
 
-var File_api_products_proto protoreflect.FileDescriptor
+// Archive selects zip archive entries to ingest.
+type Archive struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_api_products_proto_rawDesc = []byte{
-	0x0a, 0x12, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x1a, 0x1f,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
-	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
-	0x20, 0x0a, 0x0c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
-	0x6c, 0x22, 0x0f, 0x0a, 0x0d, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0xaf, 0x01, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x72, 0x69, 0x63,
-	0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x10, 0x70, 0x72, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3e, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x69,
-	0x66, 0x69, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x69,
-	0x66, 0x69, 0x65, 0x64, 0x22, 0x84, 0x02, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x06, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61, 0x67, 0x69,
-	0x6e, 0x67, 0x52, 0x06, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x37, 0x0a, 0x07, 0x73, 0x6f,
-	0x72, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72,
-	0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x6f, 0x72, 0x74,
-	0x69, 0x6e, 0x67, 0x1a, 0x45, 0x0a, 0x06, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a,
-	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69,
-	0x6d, 0x69, 0x74, 0x12, 0x25, 0x0a, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f,
-	0x64, 0x75, 0x63, 0x74, 0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x1a, 0x3f, 0x0a, 0x07, 0x53, 0x6f,
-	0x72, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69,
-	0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x73, 0x63, 0x65, 0x6e, 0x64,
-	0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x22, 0x3d, 0x0a, 0x0c, 0x4c,
-	0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x08, 0x70,
-	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
-	0x52, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x32, 0x7f, 0x0a, 0x08, 0x50, 0x72,
-	0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x12, 0x3a, 0x0a, 0x05, 0x46, 0x65, 0x74, 0x63, 0x68, 0x12,
-	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
-	0x74, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x37, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x15, 0x2e, 0x70, 0x72, 0x6f,
-	0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x17, 0x5a, 0x15, 0x70,
-	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x70, 0x62, 0x3b, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
-	0x74, 0x73, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	// glob matched against entry path or its base name, every entry if empty
+	Pattern string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// ingest every matching entry instead of the first one
+	All bool `protobuf:"varint,2,opt,name=all,proto3" json:"all,omitempty"`
 }
 
-var (
-	file_api_products_proto_rawDescOnce sync.Once
-	file_api_products_proto_rawDescData = file_api_products_proto_rawDesc
-)
+func (x *Archive) Reset() {
+	*x = Archive{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_api_products_proto_rawDescGZIP() []byte {
-	file_api_products_proto_rawDescOnce.Do(func() {
-		file_api_products_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_products_proto_rawDescData)
-	})
-	return file_api_products_proto_rawDescData
+func (x *Archive) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_api_products_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
-var file_api_products_proto_goTypes = []interface{}{
-	(*FetchRequest)(nil),          // 0: products.FetchRequest
-	(*FetchResponse)(nil),         // 1: products.FetchResponse
-	(*Product)(nil),               // 2: products.Product
-	(*ListRequest)(nil),           // 3: products.ListRequest
-	(*ListResponse)(nil),          // 4: products.ListResponse
-	(*ListRequest_Paging)(nil),    // 5: products.ListRequest.Paging
-	(*ListRequest_Sorting)(nil),   // 6: products.ListRequest.Sorting
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+func (*Archive) ProtoMessage() {}
+
+func (x *Archive) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_api_products_proto_depIdxs = []int32{
-	7, // 0: products.Product.lastModified:type_name -> google.protobuf.Timestamp
-	5, // 1: products.ListRequest.paging:type_name -> products.ListRequest.Paging
-	6, // 2: products.ListRequest.sorting:type_name -> products.ListRequest.Sorting
-	2, // 3: products.ListResponse.products:type_name -> products.Product
-	2, // 4: products.ListRequest.Paging.last:type_name -> products.Product
-	0, // 5: products.Products.Fetch:input_type -> products.FetchRequest
-	3, // 6: products.Products.List:input_type -> products.ListRequest
-	1, // 7: products.Products.Fetch:output_type -> products.FetchResponse
-	4, // 8: products.Products.List:output_type -> products.ListResponse
-	7, // [7:9] is the sub-list for method output_type
-	5, // [5:7] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+
+// Deprecated: Use Archive.ProtoReflect.Descriptor instead.
+func (*Archive) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{2}
 }
 
-func init() { file_api_products_proto_init() }
-func file_api_products_proto_init() {
-	if File_api_products_proto != nil {
-		return
+func (x *Archive) GetPattern() string {
+	if x != nil {
+		return x.Pattern
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_api_products_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_api_products_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FetchResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return ""
+}
+
+func (x *Archive) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+// feed layout, every omitted part is detected
+type FeedSchema struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// single character, detected from the first line if empty
+	Delimiter string            `protobuf:"bytes,1,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+	Quotes    FeedSchema_Quotes `protobuf:"varint,2,opt,name=quotes,proto3,enum=products.FeedSchema_Quotes" json:"quotes,omitempty"`
+	Header    FeedSchema_Header `protobuf:"varint,3,opt,name=header,proto3,enum=products.FeedSchema_Header" json:"header,omitempty"`
+	// header name, json key or xml path relative to the record element (e.g. @id, price, price/@currency)
+	// -> product field (name, price, externalId, currency)
+	// 1-based column number instead of header name for feeds without header
+	// detected by well known header names if empty, falls back to name;price layout
+	Columns map[string]string `protobuf:"bytes,4,rep,name=columns,proto3" json:"columns,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// xml only, path to record elements, e.g. shop/offers/offer, matched by suffix, offer if empty
+	RecordPath string `protobuf:"bytes,5,opt,name=recordPath,proto3" json:"recordPath,omitempty"`
+	// e.g. windows-1251, overrides response content type charset,
+	// taken from BOM, content type or xml declaration if empty, utf-8 assumed otherwise
+	Charset string `protobuf:"bytes,6,opt,name=charset,proto3" json:"charset,omitempty"`
+	// '.' or ',', detected per value if empty, values like 1,299 are rejected as ambiguous then
+	DecimalSeparator string `protobuf:"bytes,7,opt,name=decimalSeparator,proto3" json:"decimalSeparator,omitempty"`
+	// single character, spaces and apostrophes are always accepted,
+	// the one of '.' and ',' not used as decimal separator is accepted as well if empty
+	GroupSeparator string `protobuf:"bytes,8,opt,name=groupSeparator,proto3" json:"groupSeparator,omitempty"`
+}
+
+func (x *FeedSchema) Reset() {
+	*x = FeedSchema{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeedSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedSchema) ProtoMessage() {}
+
+func (x *FeedSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_api_products_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Product); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedSchema.ProtoReflect.Descriptor instead.
+func (*FeedSchema) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FeedSchema) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+func (x *FeedSchema) GetQuotes() FeedSchema_Quotes {
+	if x != nil {
+		return x.Quotes
+	}
+	return FeedSchema_STRICT
+}
+
+func (x *FeedSchema) GetHeader() FeedSchema_Header {
+	if x != nil {
+		return x.Header
+	}
+	return FeedSchema_DETECT
+}
+
+func (x *FeedSchema) GetColumns() map[string]string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *FeedSchema) GetRecordPath() string {
+	if x != nil {
+		return x.RecordPath
+	}
+	return ""
+}
+
+func (x *FeedSchema) GetCharset() string {
+	if x != nil {
+		return x.Charset
+	}
+	return ""
+}
+
+func (x *FeedSchema) GetDecimalSeparator() string {
+	if x != nil {
+		return x.DecimalSeparator
+	}
+	return ""
+}
+
+func (x *FeedSchema) GetGroupSeparator() string {
+	if x != nil {
+		return x.GroupSeparator
+	}
+	return ""
+}
+
+// what to do with rows failed to parse or refused by the DB
+type ErrorPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode ErrorPolicy_Mode `protobuf:"varint,1,opt,name=mode,proto3,enum=products.ErrorPolicy_Mode" json:"mode,omitempty"`
+	// with SKIP, aborts once more rows rejected, unlimited if zero
+	MaxRejected uint32 `protobuf:"varint,2,opt,name=maxRejected,proto3" json:"maxRejected,omitempty"`
+	// with SKIP, fails once the whole feed is read and more percent of rows rejected, unlimited if zero
+	MaxRejectedPercent float64 `protobuf:"fixed64,3,opt,name=maxRejectedPercent,proto3" json:"maxRejectedPercent,omitempty"`
+}
+
+func (x *ErrorPolicy) Reset() {
+	*x = ErrorPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorPolicy) ProtoMessage() {}
+
+func (x *ErrorPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorPolicy.ProtoReflect.Descriptor instead.
+func (*ErrorPolicy) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ErrorPolicy) GetMode() ErrorPolicy_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return ErrorPolicy_ABORT
+}
+
+func (x *ErrorPolicy) GetMaxRejected() uint32 {
+	if x != nil {
+		return x.MaxRejected
+	}
+	return 0
+}
+
+func (x *ErrorPolicy) GetMaxRejectedPercent() float64 {
+	if x != nil {
+		return x.MaxRejectedPercent
+	}
+	return 0
+}
+
+type FetchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId string `protobuf:"bytes,1,opt,name=jobId,proto3" json:"jobId,omitempty"`
+	// filled only if waited for the job to finish or for dry run
+	Report *IngestionReport `protobuf:"bytes,2,opt,name=report,proto3" json:"report,omitempty"`
+	// filled only for dry run
+	Diff *FetchDiff `protobuf:"bytes,3,opt,name=diff,proto3" json:"diff,omitempty"`
+}
+
+func (x *FetchResponse) Reset() {
+	*x = FetchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchResponse) ProtoMessage() {}
+
+func (x *FetchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchResponse.ProtoReflect.Descriptor instead.
+func (*FetchResponse) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FetchResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *FetchResponse) GetReport() *IngestionReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+func (x *FetchResponse) GetDiff() *FetchDiff {
+	if x != nil {
+		return x.Diff
+	}
+	return nil
+}
+
+// starts fetches of many feeds at once without waiting for them, jobs are polled by GetFetchJob;
+// a feed failing to start does not stop the others
+type FetchBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// url or source with options of every feed, wait is ignored, dry run and WAIT conflicts are not supported
+	Feeds []*FetchRequest `protobuf:"bytes,1,rep,name=feeds,proto3" json:"feeds,omitempty"`
+}
+
+func (x *FetchBatchRequest) Reset() {
+	*x = FetchBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchBatchRequest) ProtoMessage() {}
+
+func (x *FetchBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchBatchRequest.ProtoReflect.Descriptor instead.
+func (*FetchBatchRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *FetchBatchRequest) GetFeeds() []*FetchRequest {
+	if x != nil {
+		return x.Feeds
+	}
+	return nil
+}
+
+type FetchBatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// in the order of requested feeds
+	Results []*FetchBatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *FetchBatchResponse) Reset() {
+	*x = FetchBatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchBatchResponse) ProtoMessage() {}
+
+func (x *FetchBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchBatchResponse.ProtoReflect.Descriptor instead.
+func (*FetchBatchResponse) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FetchBatchResponse) GetResults() []*FetchBatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type FetchBatchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// url fetched, the source one if fetched by source
+	Url    string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// started or joined job, the one in flight for REJECT conflicts, empty if none
+	Job *FetchJob `protobuf:"bytes,3,opt,name=job,proto3" json:"job,omitempty"`
+	// why the job was not started, empty on success
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *FetchBatchResult) Reset() {
+	*x = FetchBatchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchBatchResult) ProtoMessage() {}
+
+func (x *FetchBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchBatchResult.ProtoReflect.Descriptor instead.
+func (*FetchBatchResult) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FetchBatchResult) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *FetchBatchResult) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *FetchBatchResult) GetJob() *FetchJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *FetchBatchResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// what Fetch would change, lists are capped by FETCH_MAX_DIFF_ITEMS,
+// report counts inserted, repriced and unchanged rows as if they were written
+type FetchDiff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Increased   uint32        `protobuf:"varint,1,opt,name=increased,proto3" json:"increased,omitempty"`
+	Decreased   uint32        `protobuf:"varint,2,opt,name=decreased,proto3" json:"decreased,omitempty"`
+	NewProducts []*Product    `protobuf:"bytes,3,rep,name=newProducts,proto3" json:"newProducts,omitempty"`
+	Increases   []*PriceDelta `protobuf:"bytes,4,rep,name=increases,proto3" json:"increases,omitempty"`
+	Decreases   []*PriceDelta `protobuf:"bytes,5,rep,name=decreases,proto3" json:"decreases,omitempty"`
+}
+
+func (x *FetchDiff) Reset() {
+	*x = FetchDiff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchDiff) ProtoMessage() {}
+
+func (x *FetchDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchDiff.ProtoReflect.Descriptor instead.
+func (*FetchDiff) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FetchDiff) GetIncreased() uint32 {
+	if x != nil {
+		return x.Increased
+	}
+	return 0
+}
+
+func (x *FetchDiff) GetDecreased() uint32 {
+	if x != nil {
+		return x.Decreased
+	}
+	return 0
+}
+
+func (x *FetchDiff) GetNewProducts() []*Product {
+	if x != nil {
+		return x.NewProducts
+	}
+	return nil
+}
+
+func (x *FetchDiff) GetIncreases() []*PriceDelta {
+	if x != nil {
+		return x.Increases
+	}
+	return nil
+}
+
+func (x *FetchDiff) GetDecreases() []*PriceDelta {
+	if x != nil {
+		return x.Decreases
+	}
+	return nil
+}
+
+type PriceDelta struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// product with the new price, id of the stored one
+	Product  *Product `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	OldPrice string   `protobuf:"bytes,2,opt,name=oldPrice,proto3" json:"oldPrice,omitempty"`
+	// new price minus old price
+	Delta string `protobuf:"bytes,3,opt,name=delta,proto3" json:"delta,omitempty"`
+}
+
+func (x *PriceDelta) Reset() {
+	*x = PriceDelta{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriceDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceDelta) ProtoMessage() {}
+
+func (x *PriceDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceDelta.ProtoReflect.Descriptor instead.
+func (*PriceDelta) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PriceDelta) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *PriceDelta) GetOldPrice() string {
+	if x != nil {
+		return x.OldPrice
+	}
+	return ""
+}
+
+func (x *PriceDelta) GetDelta() string {
+	if x != nil {
+		return x.Delta
+	}
+	return ""
+}
+
+// fetch job is stored in mongo, so any instance is able to report its state
+type FetchJob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url   string         `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	State FetchJob_State `protobuf:"varint,3,opt,name=state,proto3,enum=products.FetchJob_State" json:"state,omitempty"`
+	// instance running the job
+	Instance        string                 `protobuf:"bytes,4,opt,name=instance,proto3" json:"instance,omitempty"`
+	CancelRequested bool                   `protobuf:"varint,5,opt,name=cancelRequested,proto3" json:"cancelRequested,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	StartedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
+	FinishedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=finishedAt,proto3" json:"finishedAt,omitempty"`
+	// updated periodically by running instance, stale heartbeat means the instance is gone
+	HeartbeatAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=heartbeatAt,proto3" json:"heartbeatAt,omitempty"`
+	Report      *IngestionReport       `protobuf:"bytes,10,opt,name=report,proto3" json:"report,omitempty"`
+	Error       string                 `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+	// empty if fetched by url
+	Source string `protobuf:"bytes,12,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *FetchJob) Reset() {
+	*x = FetchJob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FetchJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchJob) ProtoMessage() {}
+
+func (x *FetchJob) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchJob.ProtoReflect.Descriptor instead.
+func (*FetchJob) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FetchJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FetchJob) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *FetchJob) GetState() FetchJob_State {
+	if x != nil {
+		return x.State
+	}
+	return FetchJob_PENDING
+}
+
+func (x *FetchJob) GetInstance() string {
+	if x != nil {
+		return x.Instance
+	}
+	return ""
+}
+
+func (x *FetchJob) GetCancelRequested() bool {
+	if x != nil {
+		return x.CancelRequested
+	}
+	return false
+}
+
+func (x *FetchJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FetchJob) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *FetchJob) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+func (x *FetchJob) GetHeartbeatAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HeartbeatAt
+	}
+	return nil
+}
+
+func (x *FetchJob) GetReport() *IngestionReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+func (x *FetchJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *FetchJob) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+// what happened to the feed rows
+// parsed = inserted + repriced + unchanged + rejected
+type IngestionReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Parsed uint32 `protobuf:"varint,1,opt,name=parsed,proto3" json:"parsed,omitempty"`
+	// inserted + repriced
+	Written   uint32 `protobuf:"varint,2,opt,name=written,proto3" json:"written,omitempty"`
+	Inserted  uint32 `protobuf:"varint,3,opt,name=inserted,proto3" json:"inserted,omitempty"`
+	Repriced  uint32 `protobuf:"varint,4,opt,name=repriced,proto3" json:"repriced,omitempty"`
+	Unchanged uint32 `protobuf:"varint,5,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	Rejected  uint32 `protobuf:"varint,6,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	// first rejected rows only, rejected holds the total count
+	Errors []*RowError `protobuf:"bytes,7,rep,name=errors,proto3" json:"errors,omitempty"`
+	// archived by full sync as missing from the feed
+	Archived uint32 `protobuf:"varint,8,opt,name=archived,proto3" json:"archived,omitempty"`
+	// archived before, listed by the feed again
+	Restored uint32 `protobuf:"varint,9,opt,name=restored,proto3" json:"restored,omitempty"`
+	// why the feed was not ingested at all: not modified or the same content as the last time,
+	// empty if it was ingested
+	Skipped string `protobuf:"bytes,10,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	// feed requests repeated after transient failures
+	Retries uint32 `protobuf:"varint,11,opt,name=retries,proto3" json:"retries,omitempty"`
+	// state of the feed host breaker after the download
+	Breaker IngestionReport_Breaker `protobuf:"varint,12,opt,name=breaker,proto3,enum=products.IngestionReport_Breaker" json:"breaker,omitempty"`
+}
+
+func (x *IngestionReport) Reset() {
+	*x = IngestionReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestionReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestionReport) ProtoMessage() {}
+
+func (x *IngestionReport) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestionReport.ProtoReflect.Descriptor instead.
+func (*IngestionReport) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IngestionReport) GetParsed() uint32 {
+	if x != nil {
+		return x.Parsed
+	}
+	return 0
+}
+
+func (x *IngestionReport) GetWritten() uint32 {
+	if x != nil {
+		return x.Written
+	}
+	return 0
+}
+
+func (x *IngestionReport) GetInserted() uint32 {
+	if x != nil {
+		return x.Inserted
+	}
+	return 0
+}
+
+func (x *IngestionReport) GetRepriced() uint32 {
+	if x != nil {
+		return x.Repriced
+	}
+	return 0
+}
+
+func (x *IngestionReport) GetUnchanged() uint32 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *IngestionReport) GetRejected() uint32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *IngestionReport) GetErrors() []*RowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *IngestionReport) GetArchived() uint32 {
+	if x != nil {
+		return x.Archived
+	}
+	return 0
+}
+
+func (x *IngestionReport) GetRestored() uint32 {
+	if x != nil {
+		return x.Restored
+	}
+	return 0
+}
+
+func (x *IngestionReport) GetSkipped() string {
+	if x != nil {
+		return x.Skipped
+	}
+	return ""
+}
+
+func (x *IngestionReport) GetRetries() uint32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *IngestionReport) GetBreaker() IngestionReport_Breaker {
+	if x != nil {
+		return x.Breaker
+	}
+	return IngestionReport_CLOSED
+}
+
+type RowError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Line   uint32 `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// raw record as read from the feed
+	Record []string `protobuf:"bytes,3,rep,name=record,proto3" json:"record,omitempty"`
+	// archive entry the row comes from
+	Entry string `protobuf:"bytes,4,opt,name=entry,proto3" json:"entry,omitempty"`
+}
+
+func (x *RowError) Reset() {
+	*x = RowError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RowError) ProtoMessage() {}
+
+func (x *RowError) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RowError.ProtoReflect.Descriptor instead.
+func (*RowError) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RowError) GetLine() uint32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *RowError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RowError) GetRecord() []string {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *RowError) GetEntry() string {
+	if x != nil {
+		return x.Entry
+	}
+	return ""
+}
+
+type GetFetchJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetFetchJobRequest) Reset() {
+	*x = GetFetchJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFetchJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFetchJobRequest) ProtoMessage() {}
+
+func (x *GetFetchJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFetchJobRequest.ProtoReflect.Descriptor instead.
+func (*GetFetchJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetFetchJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// returns a requested page of fetch jobs, newest first
+type ListFetchJobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limit  uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	LastId string `protobuf:"bytes,2,opt,name=lastId,proto3" json:"lastId,omitempty"`
+	// all states if empty
+	States []FetchJob_State `protobuf:"varint,3,rep,packed,name=states,proto3,enum=products.FetchJob_State" json:"states,omitempty"`
+}
+
+func (x *ListFetchJobsRequest) Reset() {
+	*x = ListFetchJobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFetchJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFetchJobsRequest) ProtoMessage() {}
+
+func (x *ListFetchJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFetchJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListFetchJobsRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListFetchJobsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListFetchJobsRequest) GetLastId() string {
+	if x != nil {
+		return x.LastId
+	}
+	return ""
+}
+
+func (x *ListFetchJobsRequest) GetStates() []FetchJob_State {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type ListFetchJobsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Jobs []*FetchJob `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`
+}
+
+func (x *ListFetchJobsResponse) Reset() {
+	*x = ListFetchJobsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListFetchJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFetchJobsResponse) ProtoMessage() {}
+
+func (x *ListFetchJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFetchJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListFetchJobsResponse) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListFetchJobsResponse) GetJobs() []*FetchJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+// streams all rows rejected by the job ordered by line
+type GetFetchJobErrorsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetFetchJobErrorsRequest) Reset() {
+	*x = GetFetchJobErrorsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFetchJobErrorsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFetchJobErrorsRequest) ProtoMessage() {}
+
+func (x *GetFetchJobErrorsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFetchJobErrorsRequest.ProtoReflect.Descriptor instead.
+func (*GetFetchJobErrorsRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetFetchJobErrorsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// cancels pending or running job, whatever instance is running it
+type CancelFetchJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *CancelFetchJobRequest) Reset() {
+	*x = CancelFetchJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelFetchJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelFetchJobRequest) ProtoMessage() {}
+
+func (x *CancelFetchJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelFetchJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelFetchJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CancelFetchJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Product struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Price            string                 `protobuf:"bytes,3,opt,name=price,proto3" json:"price,omitempty"`
+	PriceUpdateCount uint32                 `protobuf:"varint,4,opt,name=priceUpdateCount,proto3" json:"priceUpdateCount,omitempty"`
+	LastModified     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=lastModified,proto3" json:"lastModified,omitempty"`
+	// supplier's product id, e.g. YML offer id
+	ExternalId string `protobuf:"bytes,6,opt,name=externalId,proto3" json:"externalId,omitempty"`
+	Currency   string `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	// source name or feed url the product was last listed by
+	Source string `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"`
+	// missing from its feed, see SyncPolicy
+	Archived   bool                   `protobuf:"varint,9,opt,name=archived,proto3" json:"archived,omitempty"`
+	ArchivedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=archivedAt,proto3" json:"archivedAt,omitempty"`
+}
+
+func (x *Product) Reset() {
+	*x = Product{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Product) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Product) ProtoMessage() {}
+
+func (x *Product) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Product.ProtoReflect.Descriptor instead.
+func (*Product) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Product) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Product) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Product) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *Product) GetPriceUpdateCount() uint32 {
+	if x != nil {
+		return x.PriceUpdateCount
+	}
+	return 0
+}
+
+func (x *Product) GetLastModified() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastModified
+	}
+	return nil
+}
+
+func (x *Product) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *Product) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Product) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Product) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Product) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+// returns a requested page of products
+// able to sort by any product's field
+// what if I change sorting method for arbitrary page?
+type ListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Paging  *ListRequest_Paging  `protobuf:"bytes,1,opt,name=paging,proto3" json:"paging,omitempty"`
+	Sorting *ListRequest_Sorting `protobuf:"bytes,2,opt,name=sorting,proto3" json:"sorting,omitempty"`
+	// catalog as it was at the moment, rebuilt from the price history,
+	// products priced before the history was kept are missing
+	AsOf            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=asOf,proto3" json:"asOf,omitempty"`
+	IncludeArchived bool                   `protobuf:"varint,4,opt,name=includeArchived,proto3" json:"includeArchived,omitempty"`
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListRequest) GetPaging() *ListRequest_Paging {
+	if x != nil {
+		return x.Paging
+	}
+	return nil
+}
+
+func (x *ListRequest) GetSorting() *ListRequest_Sorting {
+	if x != nil {
+		return x.Sorting
+	}
+	return nil
+}
+
+func (x *ListRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *ListRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type ListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Products []*Product `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+// returns a requested page of price changes, oldest first
+type GetPriceHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// all products if both productId and name are empty
+	ProductId string `protobuf:"bytes,1,opt,name=productId,proto3" json:"productId,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// inclusive, unbounded if omitted
+	From *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	// exclusive, unbounded if omitted
+	To     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Limit  uint32                 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	LastId string                 `protobuf:"bytes,6,opt,name=lastId,proto3" json:"lastId,omitempty"`
+}
+
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPriceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryRequest) ProtoMessage() {}
+
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetPriceHistoryRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetPriceHistoryRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetPriceHistoryRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetPriceHistoryRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryRequest) GetLastId() string {
+	if x != nil {
+		return x.LastId
+	}
+	return ""
+}
+
+type PriceChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId string `protobuf:"bytes,2,opt,name=productId,proto3" json:"productId,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// empty for the first price of the product
+	OldPrice  string                 `protobuf:"bytes,4,opt,name=oldPrice,proto3" json:"oldPrice,omitempty"`
+	NewPrice  string                 `protobuf:"bytes,5,opt,name=newPrice,proto3" json:"newPrice,omitempty"`
+	Currency  string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	ChangedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=changedAt,proto3" json:"changedAt,omitempty"`
+	// source name or feed url the price comes from
+	Source string            `protobuf:"bytes,8,opt,name=source,proto3" json:"source,omitempty"`
+	JobId  string            `protobuf:"bytes,9,opt,name=jobId,proto3" json:"jobId,omitempty"`
+	Event  PriceChange_Event `protobuf:"varint,10,opt,name=event,proto3,enum=products.PriceChange_Event" json:"event,omitempty"`
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PriceChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PriceChange) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PriceChange) GetOldPrice() string {
+	if x != nil {
+		return x.OldPrice
+	}
+	return ""
+}
+
+func (x *PriceChange) GetNewPrice() string {
+	if x != nil {
+		return x.NewPrice
+	}
+	return ""
+}
+
+func (x *PriceChange) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PriceChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *PriceChange) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PriceChange) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *PriceChange) GetEvent() PriceChange_Event {
+	if x != nil {
+		return x.Event
+	}
+	return PriceChange_PRICE
+}
+
+type GetPriceHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Changes []*PriceChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *GetPriceHistoryResponse) Reset() {
+	*x = GetPriceHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPriceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPriceHistoryResponse) ProtoMessage() {}
+
+func (x *GetPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetPriceHistoryResponse) GetChanges() []*PriceChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// returns archived product to List, products which are not archived are returned as is
+type RestoreProductRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RestoreProductRequest) Reset() {
+	*x = RestoreProductRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductRequest) ProtoMessage() {}
+
+func (x *RestoreProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductRequest.ProtoReflect.Descriptor instead.
+func (*RestoreProductRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RestoreProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// named feed definition, Fetch by source name takes its url and options
+type Source struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// unique, immutable
+	Name    string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url     string      `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Format  FeedFormat  `protobuf:"varint,3,opt,name=format,proto3,enum=products.FeedFormat" json:"format,omitempty"`
+	Schema  *FeedSchema `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
+	Archive *Archive    `protobuf:"bytes,5,opt,name=archive,proto3" json:"archive,omitempty"`
+	// sent with every feed request and shown as is, credentials go to auth
+	Headers     map[string]string `protobuf:"bytes,6,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ErrorPolicy *ErrorPolicy      `protobuf:"bytes,7,opt,name=errorPolicy,proto3" json:"errorPolicy,omitempty"`
+	Sync        *SyncPolicy       `protobuf:"bytes,8,opt,name=sync,proto3" json:"sync,omitempty"`
+	// disabled sources can't be fetched
+	Enabled   bool                   `protobuf:"varint,9,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	// cron expression in UTC (e.g. 0 * * * *, */15 9-18 * * mon-fri, @daily),
+	// the source is fetched by one of the instances on schedule if set,
+	// runs missed while the service was down are fired once as it is back,
+	// a run is skipped while the source is being fetched
+	Schedule string `protobuf:"bytes,12,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	// output only, empty if not scheduled
+	NextRunAt *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=nextRunAt,proto3" json:"nextRunAt,omitempty"`
+	// output only
+	LastRunAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=lastRunAt,proto3" json:"lastRunAt,omitempty"`
+	// output only, job started by the last scheduled run
+	LastJobId string `protobuf:"bytes,15,opt,name=lastJobId,proto3" json:"lastJobId,omitempty"`
+	// output only, why the last scheduled run did not start
+	LastRunError string      `protobuf:"bytes,16,opt,name=lastRunError,proto3" json:"lastRunError,omitempty"`
+	Auth         *SourceAuth `protobuf:"bytes,17,opt,name=auth,proto3" json:"auth,omitempty"`
+}
+
+func (x *Source) Reset() {
+	*x = Source{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Source) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Source) ProtoMessage() {}
+
+func (x *Source) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Source.ProtoReflect.Descriptor instead.
+func (*Source) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Source) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Source) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Source) GetFormat() FeedFormat {
+	if x != nil {
+		return x.Format
+	}
+	return FeedFormat_DETECT
+}
+
+func (x *Source) GetSchema() *FeedSchema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *Source) GetArchive() *Archive {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *Source) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Source) GetErrorPolicy() *ErrorPolicy {
+	if x != nil {
+		return x.ErrorPolicy
+	}
+	return nil
+}
+
+func (x *Source) GetSync() *SyncPolicy {
+	if x != nil {
+		return x.Sync
+	}
+	return nil
+}
+
+func (x *Source) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Source) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Source) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Source) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *Source) GetNextRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return nil
+}
+
+func (x *Source) GetLastRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRunAt
+	}
+	return nil
+}
+
+func (x *Source) GetLastJobId() string {
+	if x != nil {
+		return x.LastJobId
+	}
+	return ""
+}
+
+func (x *Source) GetLastRunError() string {
+	if x != nil {
+		return x.LastRunError
+	}
+	return ""
+}
+
+func (x *Source) GetAuth() *SourceAuth {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+// Secret is given either inline or as a file in the secrets dir of the service (SECRETS_DIR),
+// the file is read on every fetch, so it may be rotated in place.
+type Secret struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// stored encrypted with SECRETS_KEY, never returned
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	File  string `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	// set instead of the inline value in responses, the secret sent back this way keeps its stored value
+	Redacted bool `protobuf:"varint,3,opt,name=redacted,proto3" json:"redacted,omitempty"`
+}
+
+func (x *Secret) Reset() {
+	*x = Secret{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Secret) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Secret) ProtoMessage() {}
+
+func (x *Secret) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Secret.ProtoReflect.Descriptor instead.
+func (*Secret) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Secret) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Secret) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *Secret) GetRedacted() bool {
+	if x != nil {
+		return x.Redacted
+	}
+	return false
+}
+
+// credentials sent to the feed server, basic auth and bearer token are mutually exclusive;
+// they are not sent on redirects to other hosts
+type SourceAuth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// basic auth if set
+	Username string  `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password *Secret `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// bearer token
+	Token *Secret `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	// e.g. X-Api-Key, set over the plain source headers
+	Headers map[string]*Secret `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// PEM encoded client certificate and key for mutual TLS
+	ClientCert *Secret `protobuf:"bytes,5,opt,name=clientCert,proto3" json:"clientCert,omitempty"`
+	ClientKey  *Secret `protobuf:"bytes,6,opt,name=clientKey,proto3" json:"clientKey,omitempty"`
+	// PEM encoded CAs to check the server certificate with instead of system ones
+	Ca *Secret `protobuf:"bytes,7,opt,name=ca,proto3" json:"ca,omitempty"`
+}
+
+func (x *SourceAuth) Reset() {
+	*x = SourceAuth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SourceAuth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceAuth) ProtoMessage() {}
+
+func (x *SourceAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SourceAuth.ProtoReflect.Descriptor instead.
+func (*SourceAuth) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SourceAuth) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SourceAuth) GetPassword() *Secret {
+	if x != nil {
+		return x.Password
+	}
+	return nil
+}
+
+func (x *SourceAuth) GetToken() *Secret {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *SourceAuth) GetHeaders() map[string]*Secret {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *SourceAuth) GetClientCert() *Secret {
+	if x != nil {
+		return x.ClientCert
+	}
+	return nil
+}
+
+func (x *SourceAuth) GetClientKey() *Secret {
+	if x != nil {
+		return x.ClientKey
+	}
+	return nil
+}
+
+func (x *SourceAuth) GetCa() *Secret {
+	if x != nil {
+		return x.Ca
+	}
+	return nil
+}
+
+// fails with ALREADY_EXISTS if the name is taken
+type CreateSourceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source *Source `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *CreateSourceRequest) Reset() {
+	*x = CreateSourceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSourceRequest) ProtoMessage() {}
+
+func (x *CreateSourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSourceRequest.ProtoReflect.Descriptor instead.
+func (*CreateSourceRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateSourceRequest) GetSource() *Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+// replaces the whole definition of the source with the same name
+type UpdateSourceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source *Source `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *UpdateSourceRequest) Reset() {
+	*x = UpdateSourceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSourceRequest) ProtoMessage() {}
+
+func (x *UpdateSourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSourceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSourceRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateSourceRequest) GetSource() *Source {
+	if x != nil {
+		return x.Source
+	}
+	return nil
+}
+
+type GetSourceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetSourceRequest) Reset() {
+	*x = GetSourceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSourceRequest) ProtoMessage() {}
+
+func (x *GetSourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSourceRequest.ProtoReflect.Descriptor instead.
+func (*GetSourceRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetSourceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// returns a requested page of sources ordered by name
+type ListSourcesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limit    uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	LastName string `protobuf:"bytes,2,opt,name=lastName,proto3" json:"lastName,omitempty"`
+}
+
+func (x *ListSourcesRequest) Reset() {
+	*x = ListSourcesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSourcesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSourcesRequest) ProtoMessage() {}
+
+func (x *ListSourcesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSourcesRequest.ProtoReflect.Descriptor instead.
+func (*ListSourcesRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListSourcesRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSourcesRequest) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+type ListSourcesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sources []*Source `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+}
+
+func (x *ListSourcesResponse) Reset() {
+	*x = ListSourcesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSourcesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSourcesResponse) ProtoMessage() {}
+
+func (x *ListSourcesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSourcesResponse.ProtoReflect.Descriptor instead.
+func (*ListSourcesResponse) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListSourcesResponse) GetSources() []*Source {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// products priced by the source are kept
+type DeleteSourceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *DeleteSourceRequest) Reset() {
+	*x = DeleteSourceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSourceRequest) ProtoMessage() {}
+
+func (x *DeleteSourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSourceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSourceRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteSourceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteSourceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteSourceResponse) Reset() {
+	*x = DeleteSourceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSourceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSourceResponse) ProtoMessage() {}
+
+func (x *DeleteSourceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSourceResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSourceResponse) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{35}
+}
+
+// the first message of the stream is the header, raw feed chunks or product batches follow, never both;
+// the call returns the finished fetch job with its ingestion report once the stream is closed and ingested,
+// failed or canceled job is returned as well, with its error; errors are returned only if the job did not run,
+// an upload waits for the job in flight for the same name or source
+type UploadFeedRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Payload:
+	//	*UploadFeedRequest_Header
+	//	*UploadFeedRequest_Chunk
+	//	*UploadFeedRequest_Products
+	Payload isUploadFeedRequest_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *UploadFeedRequest) Reset() {
+	*x = UploadFeedRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFeedRequest) ProtoMessage() {}
+
+func (x *UploadFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFeedRequest.ProtoReflect.Descriptor instead.
+func (*UploadFeedRequest) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{36}
+}
+
+func (m *UploadFeedRequest) GetPayload() isUploadFeedRequest_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *UploadFeedRequest) GetHeader() *UploadFeedHeader {
+	if x, ok := x.GetPayload().(*UploadFeedRequest_Header); ok {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *UploadFeedRequest) GetChunk() []byte {
+	if x, ok := x.GetPayload().(*UploadFeedRequest_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *UploadFeedRequest) GetProducts() *ProductBatch {
+	if x, ok := x.GetPayload().(*UploadFeedRequest_Products); ok {
+		return x.Products
+	}
+	return nil
+}
+
+type isUploadFeedRequest_Payload interface {
+	isUploadFeedRequest_Payload()
+}
+
+type UploadFeedRequest_Header struct {
+	Header *UploadFeedHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type UploadFeedRequest_Chunk struct {
+	// raw feed in any format Fetch supports, possibly compressed
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+type UploadFeedRequest_Products struct {
+	Products *ProductBatch `protobuf:"bytes,3,opt,name=products,proto3,oneof"`
+}
+
+func (*UploadFeedRequest_Header) isUploadFeedRequest_Payload() {}
+
+func (*UploadFeedRequest_Chunk) isUploadFeedRequest_Payload() {}
+
+func (*UploadFeedRequest_Products) isUploadFeedRequest_Payload() {}
+
+type UploadFeedHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// feed file name, e.g. partner.csv.gz, tells format and compression by its extension unless detected otherwise,
+	// identifies the upload in price history and full sync the way url does for Fetch
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// registered source the upload comes from, its options apply and products are attributed to it,
+	// options given along override the source ones
+	Source      string       `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	ErrorPolicy *ErrorPolicy `protobuf:"bytes,3,opt,name=errorPolicy,proto3" json:"errorPolicy,omitempty"`
+	Schema      *FeedSchema  `protobuf:"bytes,4,opt,name=schema,proto3" json:"schema,omitempty"`
+	Format      FeedFormat   `protobuf:"varint,5,opt,name=format,proto3,enum=products.FeedFormat" json:"format,omitempty"`
+	Archive     *Archive     `protobuf:"bytes,6,opt,name=archive,proto3" json:"archive,omitempty"`
+	Sync        *SyncPolicy  `protobuf:"bytes,7,opt,name=sync,proto3" json:"sync,omitempty"`
+}
+
+func (x *UploadFeedHeader) Reset() {
+	*x = UploadFeedHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadFeedHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadFeedHeader) ProtoMessage() {}
+
+func (x *UploadFeedHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadFeedHeader.ProtoReflect.Descriptor instead.
+func (*UploadFeedHeader) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UploadFeedHeader) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UploadFeedHeader) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *UploadFeedHeader) GetErrorPolicy() *ErrorPolicy {
+	if x != nil {
+		return x.ErrorPolicy
+	}
+	return nil
+}
+
+func (x *UploadFeedHeader) GetSchema() *FeedSchema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *UploadFeedHeader) GetFormat() FeedFormat {
+	if x != nil {
+		return x.Format
+	}
+	return FeedFormat_DETECT
+}
+
+func (x *UploadFeedHeader) GetArchive() *Archive {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+func (x *UploadFeedHeader) GetSync() *SyncPolicy {
+	if x != nil {
+		return x.Sync
+	}
+	return nil
+}
+
+// name, price, externalId and currency are taken, price is parsed the way feed prices are
+type ProductBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Products []*Product `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+}
+
+func (x *ProductBatch) Reset() {
+	*x = ProductBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProductBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductBatch) ProtoMessage() {}
+
+func (x *ProductBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductBatch.ProtoReflect.Descriptor instead.
+func (*ProductBatch) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ProductBatch) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type ListRequest_Paging struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limit uint32   `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Last  *Product `protobuf:"bytes,2,opt,name=last,proto3" json:"last,omitempty"`
+}
+
+func (x *ListRequest_Paging) Reset() {
+	*x = ListRequest_Paging{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRequest_Paging) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest_Paging) ProtoMessage() {}
+
+func (x *ListRequest_Paging) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest_Paging.ProtoReflect.Descriptor instead.
+func (*ListRequest_Paging) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{20, 0}
+}
+
+func (x *ListRequest_Paging) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRequest_Paging) GetLast() *Product {
+	if x != nil {
+		return x.Last
+	}
+	return nil
+}
+
+type ListRequest_Sorting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ascending bool   `protobuf:"varint,1,opt,name=ascending,proto3" json:"ascending,omitempty"`
+	SortBy    string `protobuf:"bytes,2,opt,name=sortBy,proto3" json:"sortBy,omitempty"`
+}
+
+func (x *ListRequest_Sorting) Reset() {
+	*x = ListRequest_Sorting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_products_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRequest_Sorting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest_Sorting) ProtoMessage() {}
+
+func (x *ListRequest_Sorting) ProtoReflect() protoreflect.Message {
+	mi := &file_api_products_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest_Sorting.ProtoReflect.Descriptor instead.
+func (*ListRequest_Sorting) Descriptor() ([]byte, []int) {
+	return file_api_products_proto_rawDescGZIP(), []int{20, 1}
+}
+
+func (x *ListRequest_Sorting) GetAscending() bool {
+	if x != nil {
+		return x.Ascending
+	}
+	return false
+}
+
+func (x *ListRequest_Sorting) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+var File_api_products_proto protoreflect.FileDescriptor
+
+var file_api_products_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0xd7, 0x03, 0x0a, 0x0c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75,
+	0x72, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x12, 0x37, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x52, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x2c, 0x0a,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x61,
+	0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x52,
+	0x07, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x52,
+	0x75, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e,
+	0x12, 0x28, 0x0a, 0x04, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x52, 0x04, 0x73, 0x79, 0x6e, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x52, 0x0a, 0x6f, 0x6e, 0x43, 0x6f, 0x6e,
+	0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x2c, 0x0a, 0x0a, 0x4f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x4f, 0x49,
+	0x4e, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x57, 0x41, 0x49, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a,
+	0x06, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x10, 0x02, 0x22, 0x7f, 0x0a, 0x0a, 0x53, 0x79, 0x6e,
+	0x63, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x2d, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73,
+	0x2e, 0x53, 0x79, 0x6e, 0x63, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x4d, 0x6f, 0x64, 0x65,
+	0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x64,
+	0x46, 0x65, 0x74, 0x63, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6d,
+	0x69, 0x73, 0x73, 0x65, 0x64, 0x46, 0x65, 0x74, 0x63, 0x68, 0x65, 0x73, 0x22, 0x1c, 0x0a, 0x04,
+	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x53, 0x45, 0x52, 0x54, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x46, 0x55, 0x4c, 0x4c, 0x10, 0x01, 0x22, 0x35, 0x0a, 0x07, 0x41, 0x72,
+	0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x12,
+	0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x6c,
+	0x6c, 0x22, 0xf4, 0x03, 0x0a, 0x0a, 0x46, 0x65, 0x65, 0x64, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x33,
+	0x0a, 0x06, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b,
+	0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x53, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x52, 0x06, 0x71, 0x75, 0x6f,
+	0x74, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46,
+	0x65, 0x65, 0x64, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x50,
+	0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x50, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x72, 0x73, 0x65, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x72, 0x73, 0x65, 0x74, 0x12,
+	0x2a, 0x0a, 0x10, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x64, 0x65, 0x63, 0x69, 0x6d,
+	0x61, 0x6c, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0e, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x1a, 0x3a, 0x0a, 0x0c, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x28, 0x0a, 0x06, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52,
+	0x49, 0x43, 0x54, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x41, 0x5a, 0x59, 0x10, 0x01, 0x12,
+	0x08, 0x0a, 0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x02, 0x22, 0x2d, 0x0a, 0x06, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x54, 0x45, 0x43, 0x54, 0x10, 0x00, 0x12,
+	0x0b, 0x0a, 0x07, 0x50, 0x52, 0x45, 0x53, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06,
+	0x41, 0x42, 0x53, 0x45, 0x4e, 0x54, 0x10, 0x02, 0x22, 0xac, 0x01, 0x0a, 0x0b, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x2e, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x73, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x52,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d,
+	0x61, 0x78, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x2e, 0x0a, 0x12, 0x6d, 0x61,
+	0x78, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x6a, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0x1b, 0x0a, 0x04, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x42, 0x4f, 0x52, 0x54, 0x10, 0x00, 0x12, 0x08, 0x0a,
+	0x04, 0x53, 0x4b, 0x49, 0x50, 0x10, 0x01, 0x22, 0x81, 0x01, 0x0a, 0x0d, 0x46, 0x65, 0x74, 0x63,
+	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6a, 0x6f, 0x62,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12,
+	0x31, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x27, 0x0a, 0x04, 0x64, 0x69, 0x66, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63,
+	0x68, 0x44, 0x69, 0x66, 0x66, 0x52, 0x04, 0x64, 0x69, 0x66, 0x66, 0x22, 0x41, 0x0a, 0x11, 0x46,
+	0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2c, 0x0a, 0x05, 0x66, 0x65, 0x65, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x66, 0x65, 0x65, 0x64, 0x73, 0x22, 0x4a,
+	0x0a, 0x12, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73,
+	0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x78, 0x0a, 0x10, 0x46, 0x65,
+	0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73,
+	0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x22, 0xe4, 0x01, 0x0a, 0x09, 0x46, 0x65, 0x74, 0x63, 0x68, 0x44, 0x69,
+	0x66, 0x66, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x61, 0x73, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x61, 0x73, 0x65, 0x64,
+	0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x63, 0x72, 0x65, 0x61, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x65, 0x63, 0x72, 0x65, 0x61, 0x73, 0x65, 0x64, 0x12, 0x33,
+	0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x50,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x09, 0x69, 0x6e, 0x63, 0x72, 0x65, 0x61, 0x73, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x73, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x52, 0x09, 0x69, 0x6e,
+	0x63, 0x72, 0x65, 0x61, 0x73, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x09, 0x64, 0x65, 0x63, 0x72, 0x65,
+	0x61, 0x73, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x74, 0x61,
+	0x52, 0x09, 0x64, 0x65, 0x63, 0x72, 0x65, 0x61, 0x73, 0x65, 0x73, 0x22, 0x6b, 0x0a, 0x0a, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x07, 0x70,
+	0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x6c, 0x64, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x6c, 0x64, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x22, 0xbd, 0x04, 0x0a, 0x08, 0x46, 0x65, 0x74,
+	0x63, 0x68, 0x4a, 0x6f, 0x62, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x63, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x38, 0x0a,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3c, 0x0a,
+	0x0b, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x41, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b,
+	0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x41, 0x74, 0x12, 0x31, 0x0a, 0x06, 0x72,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x06, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x4a, 0x0a, 0x05,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47,
+	0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12,
+	0x0d, 0x0a, 0x09, 0x53, 0x55, 0x43, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a,
+	0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x41,
+	0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x22, 0xba, 0x03, 0x0a, 0x0f, 0x49, 0x6e, 0x67,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x61,
+	0x72, 0x73, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e, 0x12, 0x1a,
+	0x0a, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x72, 0x65,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x6e, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x75, 0x6e, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x12, 0x2a, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x52, 0x6f, 0x77, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x72, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x07, 0x62, 0x72, 0x65, 0x61,
+	0x6b, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x73, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x2e, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x52, 0x07, 0x62, 0x72,
+	0x65, 0x61, 0x6b, 0x65, 0x72, 0x22, 0x2e, 0x0a, 0x07, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72,
+	0x12, 0x0a, 0x0a, 0x06, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
+	0x4f, 0x50, 0x45, 0x4e, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x48, 0x41, 0x4c, 0x46, 0x5f, 0x4f,
+	0x50, 0x45, 0x4e, 0x10, 0x02, 0x22, 0x64, 0x0a, 0x08, 0x52, 0x6f, 0x77, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x24, 0x0a, 0x12, 0x47,
+	0x65, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x76, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f,
+	0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x64, 0x12, 0x30, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x22, 0x3f, 0x0a, 0x15, 0x4c, 0x69, 0x73,
+	0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63,
+	0x68, 0x4a, 0x6f, 0x62, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x22, 0x2a, 0x0a, 0x18, 0x47, 0x65,
+	0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x27, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0xdb, 0x02, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x70, 0x72, 0x69, 0x63, 0x65, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x10, 0x70, 0x72, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x3e, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65,
+	0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49, 0x64, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x49,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
+	0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65,
+	0x64, 0x12, 0x3a, 0x0a, 0x0a, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x41, 0x74, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0a, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x41, 0x74, 0x22, 0xde, 0x02,
+	0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a,
+	0x06, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x52, 0x06, 0x70, 0x61, 0x67,
+	0x69, 0x6e, 0x67, 0x12, 0x37, 0x0a, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6f, 0x72, 0x74,
+	0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x2e, 0x0a, 0x04,
+	0x61, 0x73, 0x4f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x61, 0x73, 0x4f, 0x66, 0x12, 0x28, 0x0a, 0x0f,
+	0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x41, 0x72,
+	0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x1a, 0x45, 0x0a, 0x06, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x67,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x25, 0x0a, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e,
+	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x04, 0x6c, 0x61, 0x73, 0x74, 0x1a, 0x3f, 0x0a,
+	0x07, 0x53, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x73, 0x63, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x73, 0x63,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x22, 0x3d,
+	0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d,
+	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x22, 0xd4, 0x01,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6c, 0x61, 0x73, 0x74, 0x49, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61,
+	0x73, 0x74, 0x49, 0x64, 0x22, 0xee, 0x02, 0x0a, 0x0b, 0x50, 0x72, 0x69, 0x63, 0x65, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x6c, 0x64, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x6c, 0x64, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x6a, 0x6f, 0x62, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62,
+	0x49, 0x64, 0x12, 0x31, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x2e, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x09,
+	0x0a, 0x05, 0x50, 0x52, 0x49, 0x43, 0x45, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x52, 0x43,
+	0x48, 0x49, 0x56, 0x45, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x53, 0x54, 0x4f,
+	0x52, 0x45, 0x44, 0x10, 0x02, 0x22, 0x4a, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2f, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x69,
+	0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x22, 0x27, 0x0a, 0x15, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x99, 0x06, 0x0a, 0x06, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x2c, 0x0a, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x52,
+	0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x2b, 0x0a, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x73, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x07, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x12, 0x37, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73,
+	0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x37, 0x0a,
+	0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e,
+	0x53, 0x79, 0x6e, 0x63, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x04, 0x73, 0x79, 0x6e, 0x63,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x6e, 0x65,
+	0x78, 0x74, 0x52, 0x75, 0x6e, 0x41, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x52,
+	0x75, 0x6e, 0x41, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x41,
+	0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x41, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c,
+	0x6c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x28, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x41, 0x75, 0x74, 0x68, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4e, 0x0a, 0x06, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x64, 0x61, 0x63, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65,
+	0x64, 0x61, 0x63, 0x74, 0x65, 0x64, 0x22, 0x8d, 0x03, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x41, 0x75, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x2c, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12,
+	0x26, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x3b, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x73, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x41, 0x75, 0x74, 0x68, 0x2e, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x12, 0x30, 0x0a, 0x0a, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x43, 0x65,
+	0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x0a, 0x63, 0x6c, 0x69, 0x65,
+	0x6e, 0x74, 0x43, 0x65, 0x72, 0x74, 0x12, 0x2e, 0x0a, 0x09, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74,
+	0x4b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x09, 0x63, 0x6c, 0x69,
+	0x65, 0x6e, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x02, 0x63, 0x61, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x52, 0x02, 0x63, 0x61, 0x1a, 0x4c, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3f, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x3f, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28,
+	0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x26, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0x46, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x41, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2a, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x29, 0x0a, 0x13, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x16, 0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa2,
+	0x01, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x65, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x48, 0x00, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x05, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x12, 0x34, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e,
+	0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x08,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x22, 0xaa, 0x02, 0x0a, 0x10, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x65,
+	0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x73, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x2c, 0x0a,
+	0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x53, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x52, 0x06, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x2c, 0x0a, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x07, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x07, 0x61,
+	0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x79, 0x6e, 0x63, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e,
+	0x53, 0x79, 0x6e, 0x63, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x04, 0x73, 0x79, 0x6e, 0x63,
+	0x22, 0x3d, 0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x2d, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2a,
+	0x40, 0x0a, 0x0a, 0x46, 0x65, 0x65, 0x64, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x0a, 0x0a,
+	0x06, 0x44, 0x45, 0x54, 0x45, 0x43, 0x54, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x53, 0x56,
+	0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06,
+	0x4e, 0x44, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x58, 0x4d, 0x4c, 0x10,
+	0x04, 0x32, 0xc2, 0x08, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x12, 0x3a,
+	0x0a, 0x05, 0x46, 0x65, 0x74, 0x63, 0x68, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0b, 0x47, 0x65,
+	0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x52, 0x0a,
+	0x0d, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x1e,
+	0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65,
+	0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
+	0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x65,
+	0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x47, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x65, 0x74, 0x63, 0x68,
+	0x4a, 0x6f, 0x62, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e,
+	0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12,
+	0x22, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x65,
+	0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x52,
+	0x6f, 0x77, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x00, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x04, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x58, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x72, 0x69, 0x63, 0x65, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x46,
+	0x0a, 0x0e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x6f,
+	0x64, 0x75, 0x63, 0x74, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73,
+	0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0c, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x73, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x09,
+	0x47, 0x65, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73,
+	0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0b, 0x4c, 0x69, 0x73,
+	0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75,
+	0x63, 0x74, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4f, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x46, 0x65, 0x65, 0x64, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x73, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x46, 0x65, 0x65, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x2e, 0x46,
+	0x65, 0x74, 0x63, 0x68, 0x4a, 0x6f, 0x62, 0x22, 0x00, 0x28, 0x01, 0x12, 0x49, 0x0a, 0x0a, 0x46,
+	0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74,
+	0x73, 0x2e, 0x46, 0x65, 0x74, 0x63, 0x68, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x17, 0x5a, 0x15, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63,
+	0x74, 0x73, 0x70, 0x62, 0x3b, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x73, 0x70, 0x62, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_products_proto_rawDescOnce sync.Once
+	file_api_products_proto_rawDescData = file_api_products_proto_rawDesc
+)
+
+func file_api_products_proto_rawDescGZIP() []byte {
+	file_api_products_proto_rawDescOnce.Do(func() {
+		file_api_products_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_products_proto_rawDescData)
+	})
+	return file_api_products_proto_rawDescData
+}
+
+var file_api_products_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_api_products_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
+var file_api_products_proto_goTypes = []interface{}{
+	(FeedFormat)(0),                  // 0: products.FeedFormat
+	(FetchRequest_OnConflict)(0),     // 1: products.FetchRequest.OnConflict
+	(SyncPolicy_Mode)(0),             // 2: products.SyncPolicy.Mode
+	(FeedSchema_Quotes)(0),           // 3: products.FeedSchema.Quotes
+	(FeedSchema_Header)(0),           // 4: products.FeedSchema.Header
+	(ErrorPolicy_Mode)(0),            // 5: products.ErrorPolicy.Mode
+	(FetchJob_State)(0),              // 6: products.FetchJob.State
+	(IngestionReport_Breaker)(0),     // 7: products.IngestionReport.Breaker
+	(PriceChange_Event)(0),           // 8: products.PriceChange.Event
+	(*FetchRequest)(nil),             // 9: products.FetchRequest
+	(*SyncPolicy)(nil),               // 10: products.SyncPolicy
+	(*Archive)(nil),                  // 11: products.Archive
+	(*FeedSchema)(nil),               // 12: products.FeedSchema
+	(*ErrorPolicy)(nil),              // 13: products.ErrorPolicy
+	(*FetchResponse)(nil),            // 14: products.FetchResponse
+	(*FetchBatchRequest)(nil),        // 15: products.FetchBatchRequest
+	(*FetchBatchResponse)(nil),       // 16: products.FetchBatchResponse
+	(*FetchBatchResult)(nil),         // 17: products.FetchBatchResult
+	(*FetchDiff)(nil),                // 18: products.FetchDiff
+	(*PriceDelta)(nil),               // 19: products.PriceDelta
+	(*FetchJob)(nil),                 // 20: products.FetchJob
+	(*IngestionReport)(nil),          // 21: products.IngestionReport
+	(*RowError)(nil),                 // 22: products.RowError
+	(*GetFetchJobRequest)(nil),       // 23: products.GetFetchJobRequest
+	(*ListFetchJobsRequest)(nil),     // 24: products.ListFetchJobsRequest
+	(*ListFetchJobsResponse)(nil),    // 25: products.ListFetchJobsResponse
+	(*GetFetchJobErrorsRequest)(nil), // 26: products.GetFetchJobErrorsRequest
+	(*CancelFetchJobRequest)(nil),    // 27: products.CancelFetchJobRequest
+	(*Product)(nil),                  // 28: products.Product
+	(*ListRequest)(nil),              // 29: products.ListRequest
+	(*ListResponse)(nil),             // 30: products.ListResponse
+	(*GetPriceHistoryRequest)(nil),   // 31: products.GetPriceHistoryRequest
+	(*PriceChange)(nil),              // 32: products.PriceChange
+	(*GetPriceHistoryResponse)(nil),  // 33: products.GetPriceHistoryResponse
+	(*RestoreProductRequest)(nil),    // 34: products.RestoreProductRequest
+	(*Source)(nil),                   // 35: products.Source
+	(*Secret)(nil),                   // 36: products.Secret
+	(*SourceAuth)(nil),               // 37: products.SourceAuth
+	(*CreateSourceRequest)(nil),      // 38: products.CreateSourceRequest
+	(*UpdateSourceRequest)(nil),      // 39: products.UpdateSourceRequest
+	(*GetSourceRequest)(nil),         // 40: products.GetSourceRequest
+	(*ListSourcesRequest)(nil),       // 41: products.ListSourcesRequest
+	(*ListSourcesResponse)(nil),      // 42: products.ListSourcesResponse
+	(*DeleteSourceRequest)(nil),      // 43: products.DeleteSourceRequest
+	(*DeleteSourceResponse)(nil),     // 44: products.DeleteSourceResponse
+	(*UploadFeedRequest)(nil),        // 45: products.UploadFeedRequest
+	(*UploadFeedHeader)(nil),         // 46: products.UploadFeedHeader
+	(*ProductBatch)(nil),             // 47: products.ProductBatch
+	nil,                              // 48: products.FeedSchema.ColumnsEntry
+	(*ListRequest_Paging)(nil),       // 49: products.ListRequest.Paging
+	(*ListRequest_Sorting)(nil),      // 50: products.ListRequest.Sorting
+	nil,                              // 51: products.Source.HeadersEntry
+	nil,                              // 52: products.SourceAuth.HeadersEntry
+	(*timestamppb.Timestamp)(nil),    // 53: google.protobuf.Timestamp
+}
+var file_api_products_proto_depIdxs = []int32{
+	13, // 0: products.FetchRequest.errorPolicy:type_name -> products.ErrorPolicy
+	12, // 1: products.FetchRequest.schema:type_name -> products.FeedSchema
+	0,  // 2: products.FetchRequest.format:type_name -> products.FeedFormat
+	11, // 3: products.FetchRequest.archive:type_name -> products.Archive
+	10, // 4: products.FetchRequest.sync:type_name -> products.SyncPolicy
+	1,  // 5: products.FetchRequest.onConflict:type_name -> products.FetchRequest.OnConflict
+	2,  // 6: products.SyncPolicy.mode:type_name -> products.SyncPolicy.Mode
+	3,  // 7: products.FeedSchema.quotes:type_name -> products.FeedSchema.Quotes
+	4,  // 8: products.FeedSchema.header:type_name -> products.FeedSchema.Header
+	48, // 9: products.FeedSchema.columns:type_name -> products.FeedSchema.ColumnsEntry
+	5,  // 10: products.ErrorPolicy.mode:type_name -> products.ErrorPolicy.Mode
+	21, // 11: products.FetchResponse.report:type_name -> products.IngestionReport
+	18, // 12: products.FetchResponse.diff:type_name -> products.FetchDiff
+	9,  // 13: products.FetchBatchRequest.feeds:type_name -> products.FetchRequest
+	17, // 14: products.FetchBatchResponse.results:type_name -> products.FetchBatchResult
+	20, // 15: products.FetchBatchResult.job:type_name -> products.FetchJob
+	28, // 16: products.FetchDiff.newProducts:type_name -> products.Product
+	19, // 17: products.FetchDiff.increases:type_name -> products.PriceDelta
+	19, // 18: products.FetchDiff.decreases:type_name -> products.PriceDelta
+	28, // 19: products.PriceDelta.product:type_name -> products.Product
+	6,  // 20: products.FetchJob.state:type_name -> products.FetchJob.State
+	53, // 21: products.FetchJob.createdAt:type_name -> google.protobuf.Timestamp
+	53, // 22: products.FetchJob.startedAt:type_name -> google.protobuf.Timestamp
+	53, // 23: products.FetchJob.finishedAt:type_name -> google.protobuf.Timestamp
+	53, // 24: products.FetchJob.heartbeatAt:type_name -> google.protobuf.Timestamp
+	21, // 25: products.FetchJob.report:type_name -> products.IngestionReport
+	22, // 26: products.IngestionReport.errors:type_name -> products.RowError
+	7,  // 27: products.IngestionReport.breaker:type_name -> products.IngestionReport.Breaker
+	6,  // 28: products.ListFetchJobsRequest.states:type_name -> products.FetchJob.State
+	20, // 29: products.ListFetchJobsResponse.jobs:type_name -> products.FetchJob
+	53, // 30: products.Product.lastModified:type_name -> google.protobuf.Timestamp
+	53, // 31: products.Product.archivedAt:type_name -> google.protobuf.Timestamp
+	49, // 32: products.ListRequest.paging:type_name -> products.ListRequest.Paging
+	50, // 33: products.ListRequest.sorting:type_name -> products.ListRequest.Sorting
+	53, // 34: products.ListRequest.asOf:type_name -> google.protobuf.Timestamp
+	28, // 35: products.ListResponse.products:type_name -> products.Product
+	53, // 36: products.GetPriceHistoryRequest.from:type_name -> google.protobuf.Timestamp
+	53, // 37: products.GetPriceHistoryRequest.to:type_name -> google.protobuf.Timestamp
+	53, // 38: products.PriceChange.changedAt:type_name -> google.protobuf.Timestamp
+	8,  // 39: products.PriceChange.event:type_name -> products.PriceChange.Event
+	32, // 40: products.GetPriceHistoryResponse.changes:type_name -> products.PriceChange
+	0,  // 41: products.Source.format:type_name -> products.FeedFormat
+	12, // 42: products.Source.schema:type_name -> products.FeedSchema
+	11, // 43: products.Source.archive:type_name -> products.Archive
+	51, // 44: products.Source.headers:type_name -> products.Source.HeadersEntry
+	13, // 45: products.Source.errorPolicy:type_name -> products.ErrorPolicy
+	10, // 46: products.Source.sync:type_name -> products.SyncPolicy
+	53, // 47: products.Source.createdAt:type_name -> google.protobuf.Timestamp
+	53, // 48: products.Source.updatedAt:type_name -> google.protobuf.Timestamp
+	53, // 49: products.Source.nextRunAt:type_name -> google.protobuf.Timestamp
+	53, // 50: products.Source.lastRunAt:type_name -> google.protobuf.Timestamp
+	37, // 51: products.Source.auth:type_name -> products.SourceAuth
+	36, // 52: products.SourceAuth.password:type_name -> products.Secret
+	36, // 53: products.SourceAuth.token:type_name -> products.Secret
+	52, // 54: products.SourceAuth.headers:type_name -> products.SourceAuth.HeadersEntry
+	36, // 55: products.SourceAuth.clientCert:type_name -> products.Secret
+	36, // 56: products.SourceAuth.clientKey:type_name -> products.Secret
+	36, // 57: products.SourceAuth.ca:type_name -> products.Secret
+	35, // 58: products.CreateSourceRequest.source:type_name -> products.Source
+	35, // 59: products.UpdateSourceRequest.source:type_name -> products.Source
+	35, // 60: products.ListSourcesResponse.sources:type_name -> products.Source
+	46, // 61: products.UploadFeedRequest.header:type_name -> products.UploadFeedHeader
+	47, // 62: products.UploadFeedRequest.products:type_name -> products.ProductBatch
+	13, // 63: products.UploadFeedHeader.errorPolicy:type_name -> products.ErrorPolicy
+	12, // 64: products.UploadFeedHeader.schema:type_name -> products.FeedSchema
+	0,  // 65: products.UploadFeedHeader.format:type_name -> products.FeedFormat
+	11, // 66: products.UploadFeedHeader.archive:type_name -> products.Archive
+	10, // 67: products.UploadFeedHeader.sync:type_name -> products.SyncPolicy
+	28, // 68: products.ProductBatch.products:type_name -> products.Product
+	28, // 69: products.ListRequest.Paging.last:type_name -> products.Product
+	36, // 70: products.SourceAuth.HeadersEntry.value:type_name -> products.Secret
+	9,  // 71: products.Products.Fetch:input_type -> products.FetchRequest
+	23, // 72: products.Products.GetFetchJob:input_type -> products.GetFetchJobRequest
+	24, // 73: products.Products.ListFetchJobs:input_type -> products.ListFetchJobsRequest
+	27, // 74: products.Products.CancelFetchJob:input_type -> products.CancelFetchJobRequest
+	26, // 75: products.Products.GetFetchJobErrors:input_type -> products.GetFetchJobErrorsRequest
+	29, // 76: products.Products.List:input_type -> products.ListRequest
+	31, // 77: products.Products.GetPriceHistory:input_type -> products.GetPriceHistoryRequest
+	34, // 78: products.Products.RestoreProduct:input_type -> products.RestoreProductRequest
+	38, // 79: products.Products.CreateSource:input_type -> products.CreateSourceRequest
+	39, // 80: products.Products.UpdateSource:input_type -> products.UpdateSourceRequest
+	40, // 81: products.Products.GetSource:input_type -> products.GetSourceRequest
+	41, // 82: products.Products.ListSources:input_type -> products.ListSourcesRequest
+	43, // 83: products.Products.DeleteSource:input_type -> products.DeleteSourceRequest
+	45, // 84: products.Products.UploadFeed:input_type -> products.UploadFeedRequest
+	15, // 85: products.Products.FetchBatch:input_type -> products.FetchBatchRequest
+	14, // 86: products.Products.Fetch:output_type -> products.FetchResponse
+	20, // 87: products.Products.GetFetchJob:output_type -> products.FetchJob
+	25, // 88: products.Products.ListFetchJobs:output_type -> products.ListFetchJobsResponse
+	20, // 89: products.Products.CancelFetchJob:output_type -> products.FetchJob
+	22, // 90: products.Products.GetFetchJobErrors:output_type -> products.RowError
+	30, // 91: products.Products.List:output_type -> products.ListResponse
+	33, // 92: products.Products.GetPriceHistory:output_type -> products.GetPriceHistoryResponse
+	28, // 93: products.Products.RestoreProduct:output_type -> products.Product
+	35, // 94: products.Products.CreateSource:output_type -> products.Source
+	35, // 95: products.Products.UpdateSource:output_type -> products.Source
+	35, // 96: products.Products.GetSource:output_type -> products.Source
+	42, // 97: products.Products.ListSources:output_type -> products.ListSourcesResponse
+	44, // 98: products.Products.DeleteSource:output_type -> products.DeleteSourceResponse
+	20, // 99: products.Products.UploadFeed:output_type -> products.FetchJob
+	16, // 100: products.Products.FetchBatch:output_type -> products.FetchBatchResponse
+	86, // [86:101] is the sub-list for method output_type
+	71, // [71:86] is the sub-list for method input_type
+	71, // [71:71] is the sub-list for extension type_name
+	71, // [71:71] is the sub-list for extension extendee
+	0,  // [0:71] is the sub-list for field type_name
+}
+
+func init() { file_api_products_proto_init() }
+func file_api_products_proto_init() {
+	if File_api_products_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_products_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Archive); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeedSchema); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchBatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchBatchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriceDelta); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FetchJob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
 			}
 		}
-		file_api_products_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_api_products_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestionReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RowError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFetchJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListFetchJobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListFetchJobsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFetchJobErrorsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelFetchJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Product); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListRequest); i {
 			case 0:
 				return &v.state
@@ -551,7 +4477,7 @@ func file_api_products_proto_init() {
 				return nil
 			}
 		}
-		file_api_products_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_api_products_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListResponse); i {
 			case 0:
 				return &v.state
@@ -563,7 +4489,211 @@ func file_api_products_proto_init() {
 				return nil
 			}
 		}
-		file_api_products_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_api_products_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPriceHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriceChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetPriceHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreProductRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Source); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Secret); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SourceAuth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSourceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateSourceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSourceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSourcesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSourcesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSourceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteSourceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadFeedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadFeedHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProductBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_products_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListRequest_Paging); i {
 			case 0:
 				return &v.state
@@ -575,7 +4705,7 @@ func file_api_products_proto_init() {
 				return nil
 			}
 		}
-		file_api_products_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_api_products_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListRequest_Sorting); i {
 			case 0:
 				return &v.state
@@ -588,18 +4718,24 @@ func file_api_products_proto_init() {
 			}
 		}
 	}
+	file_api_products_proto_msgTypes[36].OneofWrappers = []interface{}{
+		(*UploadFeedRequest_Header)(nil),
+		(*UploadFeedRequest_Chunk)(nil),
+		(*UploadFeedRequest_Products)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_products_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      9,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_api_products_proto_goTypes,
 		DependencyIndexes: file_api_products_proto_depIdxs,
+		EnumInfos:         file_api_products_proto_enumTypes,
 		MessageInfos:      file_api_products_proto_msgTypes,
 	}.Build()
 	File_api_products_proto = out.File
@@ -18,7 +18,20 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ProductsClient interface {
 	Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error)
+	GetFetchJob(ctx context.Context, in *GetFetchJobRequest, opts ...grpc.CallOption) (*FetchJob, error)
+	ListFetchJobs(ctx context.Context, in *ListFetchJobsRequest, opts ...grpc.CallOption) (*ListFetchJobsResponse, error)
+	CancelFetchJob(ctx context.Context, in *CancelFetchJobRequest, opts ...grpc.CallOption) (*FetchJob, error)
+	GetFetchJobErrors(ctx context.Context, in *GetFetchJobErrorsRequest, opts ...grpc.CallOption) (Products_GetFetchJobErrorsClient, error)
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error)
+	RestoreProduct(ctx context.Context, in *RestoreProductRequest, opts ...grpc.CallOption) (*Product, error)
+	CreateSource(ctx context.Context, in *CreateSourceRequest, opts ...grpc.CallOption) (*Source, error)
+	UpdateSource(ctx context.Context, in *UpdateSourceRequest, opts ...grpc.CallOption) (*Source, error)
+	GetSource(ctx context.Context, in *GetSourceRequest, opts ...grpc.CallOption) (*Source, error)
+	ListSources(ctx context.Context, in *ListSourcesRequest, opts ...grpc.CallOption) (*ListSourcesResponse, error)
+	DeleteSource(ctx context.Context, in *DeleteSourceRequest, opts ...grpc.CallOption) (*DeleteSourceResponse, error)
+	UploadFeed(ctx context.Context, opts ...grpc.CallOption) (Products_UploadFeedClient, error)
+	FetchBatch(ctx context.Context, in *FetchBatchRequest, opts ...grpc.CallOption) (*FetchBatchResponse, error)
 }
 
 type productsClient struct {
@@ -38,6 +51,65 @@ func (c *productsClient) Fetch(ctx context.Context, in *FetchRequest, opts ...gr
 	return out, nil
 }
 
+func (c *productsClient) GetFetchJob(ctx context.Context, in *GetFetchJobRequest, opts ...grpc.CallOption) (*FetchJob, error) {
+	out := new(FetchJob)
+	err := c.cc.Invoke(ctx, "/products.Products/GetFetchJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsClient) ListFetchJobs(ctx context.Context, in *ListFetchJobsRequest, opts ...grpc.CallOption) (*ListFetchJobsResponse, error) {
+	out := new(ListFetchJobsResponse)
+	err := c.cc.Invoke(ctx, "/products.Products/ListFetchJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsClient) CancelFetchJob(ctx context.Context, in *CancelFetchJobRequest, opts ...grpc.CallOption) (*FetchJob, error) {
+	out := new(FetchJob)
+	err := c.cc.Invoke(ctx, "/products.Products/CancelFetchJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsClient) GetFetchJobErrors(ctx context.Context, in *GetFetchJobErrorsRequest, opts ...grpc.CallOption) (Products_GetFetchJobErrorsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Products_serviceDesc.Streams[0], "/products.Products/GetFetchJobErrors", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productsGetFetchJobErrorsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Products_GetFetchJobErrorsClient interface {
+	Recv() (*RowError, error)
+	grpc.ClientStream
+}
+
+type productsGetFetchJobErrorsClient struct {
+	grpc.ClientStream
+}
+
+func (x *productsGetFetchJobErrorsClient) Recv() (*RowError, error) {
+	m := new(RowError)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *productsClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
 	out := new(ListResponse)
 	err := c.cc.Invoke(ctx, "/products.Products/List", in, out, opts...)
@@ -47,12 +119,131 @@ func (c *productsClient) List(ctx context.Context, in *ListRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *productsClient) GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error) {
+	out := new(GetPriceHistoryResponse)
+	err := c.cc.Invoke(ctx, "/products.Products/GetPriceHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsClient) RestoreProduct(ctx context.Context, in *RestoreProductRequest, opts ...grpc.CallOption) (*Product, error) {
+	out := new(Product)
+	err := c.cc.Invoke(ctx, "/products.Products/RestoreProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsClient) CreateSource(ctx context.Context, in *CreateSourceRequest, opts ...grpc.CallOption) (*Source, error) {
+	out := new(Source)
+	err := c.cc.Invoke(ctx, "/products.Products/CreateSource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsClient) UpdateSource(ctx context.Context, in *UpdateSourceRequest, opts ...grpc.CallOption) (*Source, error) {
+	out := new(Source)
+	err := c.cc.Invoke(ctx, "/products.Products/UpdateSource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsClient) GetSource(ctx context.Context, in *GetSourceRequest, opts ...grpc.CallOption) (*Source, error) {
+	out := new(Source)
+	err := c.cc.Invoke(ctx, "/products.Products/GetSource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsClient) ListSources(ctx context.Context, in *ListSourcesRequest, opts ...grpc.CallOption) (*ListSourcesResponse, error) {
+	out := new(ListSourcesResponse)
+	err := c.cc.Invoke(ctx, "/products.Products/ListSources", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsClient) DeleteSource(ctx context.Context, in *DeleteSourceRequest, opts ...grpc.CallOption) (*DeleteSourceResponse, error) {
+	out := new(DeleteSourceResponse)
+	err := c.cc.Invoke(ctx, "/products.Products/DeleteSource", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsClient) UploadFeed(ctx context.Context, opts ...grpc.CallOption) (Products_UploadFeedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Products_serviceDesc.Streams[1], "/products.Products/UploadFeed", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productsUploadFeedClient{stream}
+	return x, nil
+}
+
+type Products_UploadFeedClient interface {
+	Send(*UploadFeedRequest) error
+	CloseAndRecv() (*FetchJob, error)
+	grpc.ClientStream
+}
+
+type productsUploadFeedClient struct {
+	grpc.ClientStream
+}
+
+func (x *productsUploadFeedClient) Send(m *UploadFeedRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *productsUploadFeedClient) CloseAndRecv() (*FetchJob, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(FetchJob)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *productsClient) FetchBatch(ctx context.Context, in *FetchBatchRequest, opts ...grpc.CallOption) (*FetchBatchResponse, error) {
+	out := new(FetchBatchResponse)
+	err := c.cc.Invoke(ctx, "/products.Products/FetchBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProductsServer is the server API for Products service.
 // All implementations must embed UnimplementedProductsServer
 // for forward compatibility
 type ProductsServer interface {
 	Fetch(context.Context, *FetchRequest) (*FetchResponse, error)
+	GetFetchJob(context.Context, *GetFetchJobRequest) (*FetchJob, error)
+	ListFetchJobs(context.Context, *ListFetchJobsRequest) (*ListFetchJobsResponse, error)
+	CancelFetchJob(context.Context, *CancelFetchJobRequest) (*FetchJob, error)
+	GetFetchJobErrors(*GetFetchJobErrorsRequest, Products_GetFetchJobErrorsServer) error
 	List(context.Context, *ListRequest) (*ListResponse, error)
+	GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error)
+	RestoreProduct(context.Context, *RestoreProductRequest) (*Product, error)
+	CreateSource(context.Context, *CreateSourceRequest) (*Source, error)
+	UpdateSource(context.Context, *UpdateSourceRequest) (*Source, error)
+	GetSource(context.Context, *GetSourceRequest) (*Source, error)
+	ListSources(context.Context, *ListSourcesRequest) (*ListSourcesResponse, error)
+	DeleteSource(context.Context, *DeleteSourceRequest) (*DeleteSourceResponse, error)
+	UploadFeed(Products_UploadFeedServer) error
+	FetchBatch(context.Context, *FetchBatchRequest) (*FetchBatchResponse, error)
 	mustEmbedUnimplementedProductsServer()
 }
 
@@ -63,9 +254,48 @@ type UnimplementedProductsServer struct {
 func (UnimplementedProductsServer) Fetch(context.Context, *FetchRequest) (*FetchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Fetch not implemented")
 }
+func (UnimplementedProductsServer) GetFetchJob(context.Context, *GetFetchJobRequest) (*FetchJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFetchJob not implemented")
+}
+func (UnimplementedProductsServer) ListFetchJobs(context.Context, *ListFetchJobsRequest) (*ListFetchJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFetchJobs not implemented")
+}
+func (UnimplementedProductsServer) CancelFetchJob(context.Context, *CancelFetchJobRequest) (*FetchJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelFetchJob not implemented")
+}
+func (UnimplementedProductsServer) GetFetchJobErrors(*GetFetchJobErrorsRequest, Products_GetFetchJobErrorsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetFetchJobErrors not implemented")
+}
 func (UnimplementedProductsServer) List(context.Context, *ListRequest) (*ListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedProductsServer) GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
+func (UnimplementedProductsServer) RestoreProduct(context.Context, *RestoreProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreProduct not implemented")
+}
+func (UnimplementedProductsServer) CreateSource(context.Context, *CreateSourceRequest) (*Source, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSource not implemented")
+}
+func (UnimplementedProductsServer) UpdateSource(context.Context, *UpdateSourceRequest) (*Source, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSource not implemented")
+}
+func (UnimplementedProductsServer) GetSource(context.Context, *GetSourceRequest) (*Source, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSource not implemented")
+}
+func (UnimplementedProductsServer) ListSources(context.Context, *ListSourcesRequest) (*ListSourcesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSources not implemented")
+}
+func (UnimplementedProductsServer) DeleteSource(context.Context, *DeleteSourceRequest) (*DeleteSourceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSource not implemented")
+}
+func (UnimplementedProductsServer) UploadFeed(Products_UploadFeedServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadFeed not implemented")
+}
+func (UnimplementedProductsServer) FetchBatch(context.Context, *FetchBatchRequest) (*FetchBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FetchBatch not implemented")
+}
 func (UnimplementedProductsServer) mustEmbedUnimplementedProductsServer() {}
 
 // UnsafeProductsServer may be embedded to opt out of forward compatibility for this service.
@@ -97,6 +327,81 @@ func _Products_Fetch_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Products_GetFetchJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFetchJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).GetFetchJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/products.Products/GetFetchJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).GetFetchJob(ctx, req.(*GetFetchJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Products_ListFetchJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFetchJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).ListFetchJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/products.Products/ListFetchJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).ListFetchJobs(ctx, req.(*ListFetchJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Products_CancelFetchJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelFetchJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).CancelFetchJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/products.Products/CancelFetchJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).CancelFetchJob(ctx, req.(*CancelFetchJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Products_GetFetchJobErrors_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetFetchJobErrorsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductsServer).GetFetchJobErrors(m, &productsGetFetchJobErrorsServer{stream})
+}
+
+type Products_GetFetchJobErrorsServer interface {
+	Send(*RowError) error
+	grpc.ServerStream
+}
+
+type productsGetFetchJobErrorsServer struct {
+	grpc.ServerStream
+}
+
+func (x *productsGetFetchJobErrorsServer) Send(m *RowError) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Products_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListRequest)
 	if err := dec(in); err != nil {
@@ -115,6 +420,176 @@ func _Products_List_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Products_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).GetPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/products.Products/GetPriceHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).GetPriceHistory(ctx, req.(*GetPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Products_RestoreProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).RestoreProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/products.Products/RestoreProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).RestoreProduct(ctx, req.(*RestoreProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Products_CreateSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).CreateSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/products.Products/CreateSource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).CreateSource(ctx, req.(*CreateSourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Products_UpdateSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).UpdateSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/products.Products/UpdateSource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).UpdateSource(ctx, req.(*UpdateSourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Products_GetSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).GetSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/products.Products/GetSource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).GetSource(ctx, req.(*GetSourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Products_ListSources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSourcesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).ListSources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/products.Products/ListSources",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).ListSources(ctx, req.(*ListSourcesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Products_DeleteSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSourceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).DeleteSource(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/products.Products/DeleteSource",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).DeleteSource(ctx, req.(*DeleteSourceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Products_UploadFeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProductsServer).UploadFeed(&productsUploadFeedServer{stream})
+}
+
+type Products_UploadFeedServer interface {
+	SendAndClose(*FetchJob) error
+	Recv() (*UploadFeedRequest, error)
+	grpc.ServerStream
+}
+
+type productsUploadFeedServer struct {
+	grpc.ServerStream
+}
+
+func (x *productsUploadFeedServer) SendAndClose(m *FetchJob) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *productsUploadFeedServer) Recv() (*UploadFeedRequest, error) {
+	m := new(UploadFeedRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Products_FetchBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServer).FetchBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/products.Products/FetchBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServer).FetchBatch(ctx, req.(*FetchBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Products_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "products.Products",
 	HandlerType: (*ProductsServer)(nil),
@@ -123,11 +598,66 @@ var _Products_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Fetch",
 			Handler:    _Products_Fetch_Handler,
 		},
+		{
+			MethodName: "GetFetchJob",
+			Handler:    _Products_GetFetchJob_Handler,
+		},
+		{
+			MethodName: "ListFetchJobs",
+			Handler:    _Products_ListFetchJobs_Handler,
+		},
+		{
+			MethodName: "CancelFetchJob",
+			Handler:    _Products_CancelFetchJob_Handler,
+		},
 		{
 			MethodName: "List",
 			Handler:    _Products_List_Handler,
 		},
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _Products_GetPriceHistory_Handler,
+		},
+		{
+			MethodName: "RestoreProduct",
+			Handler:    _Products_RestoreProduct_Handler,
+		},
+		{
+			MethodName: "CreateSource",
+			Handler:    _Products_CreateSource_Handler,
+		},
+		{
+			MethodName: "UpdateSource",
+			Handler:    _Products_UpdateSource_Handler,
+		},
+		{
+			MethodName: "GetSource",
+			Handler:    _Products_GetSource_Handler,
+		},
+		{
+			MethodName: "ListSources",
+			Handler:    _Products_ListSources_Handler,
+		},
+		{
+			MethodName: "DeleteSource",
+			Handler:    _Products_DeleteSource_Handler,
+		},
+		{
+			MethodName: "FetchBatch",
+			Handler:    _Products_FetchBatch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetFetchJobErrors",
+			Handler:       _Products_GetFetchJobErrors_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadFeed",
+			Handler:       _Products_UploadFeed_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/products.proto",
 }